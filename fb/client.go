@@ -45,7 +45,8 @@ func reduceLimit(rawURL string) (string, bool) {
 
 // Client holds an http.Client and provides additional functionality.
 type Client struct {
-	l log.Logger
+	l         log.Logger
+	appSecret string
 	*http.Client
 }
 
@@ -67,11 +68,23 @@ func NewClientWithConfig(l log.Logger, token, clientKey string, cfg RateLimitCon
 	)
 
 	return &Client{
-		l:      l,
-		Client: &http.Client{Transport: transport},
+		l:         l,
+		appSecret: clientKey,
+		Client:    &http.Client{Transport: transport},
 	}
 }
 
+// SignPayload returns the hex encoded HMAC-SHA256 of b keyed with the app secret.
+// Meta signs webhook payloads the same way in the X-Hub-Signature-256 header.
+func (c *Client) SignPayload(b []byte) string {
+	return hmacSHA256(c.appSecret, b)
+}
+
+// HasAppSecret returns true if the client was created with an app secret.
+func (c *Client) HasAppSecret() bool {
+	return c.appSecret != ""
+}
+
 func (c *Client) handleResponse(resp *http.Response, res interface{}, req []byte) error {
 	defer resp.Body.Close()
 
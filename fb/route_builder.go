@@ -177,6 +177,28 @@ func (rb *RouteBuilder) Since(t time.Time) *RouteBuilder {
 	return rb
 }
 
+// Until sets the until param (unix timestamp) or deletes it when t is zero.
+func (rb *RouteBuilder) Until(t time.Time) *RouteBuilder {
+	if t.IsZero() {
+		rb.v.Del("until")
+	} else {
+		rb.v.Set("until", strconv.FormatInt(t.Unix(), 10))
+	}
+
+	return rb
+}
+
+// Category sets the category param or deletes it.
+func (rb *RouteBuilder) Category(s string) *RouteBuilder {
+	if s != "" {
+		rb.v.Set("category", s)
+	} else {
+		rb.v.Del("category")
+	}
+
+	return rb
+}
+
 // DatePreset sets date_preset param and deletes the time_range one.
 func (rb *RouteBuilder) DatePreset(s string) *RouteBuilder {
 	if s != "" {
@@ -241,6 +263,17 @@ func (rb *RouteBuilder) EffectiveStatus(s ...string) *RouteBuilder {
 	return rb
 }
 
+// SessionID sets the session_id param or deletes it.
+func (rb *RouteBuilder) SessionID(s string) *RouteBuilder {
+	if s != "" {
+		rb.v.Set("session_id", s)
+	} else {
+		rb.v.Del("session_id")
+	}
+
+	return rb
+}
+
 // AdFormat sets the ad_format param or deletes it.
 func (rb *RouteBuilder) AdFormat(s string) *RouteBuilder {
 	if len(s) > 0 {
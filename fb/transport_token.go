@@ -66,8 +66,12 @@ type tokenKey struct{}
 var tk tokenKey
 
 func (t *tokenTransport) getAppSecretProof(ctx context.Context) string {
-	h := hmac.New(sha256.New, []byte(t.clientKey))
-	h.Write([]byte(t.getAccessToken(ctx)))
+	return hmacSHA256(t.clientKey, []byte(t.getAccessToken(ctx)))
+}
+
+func hmacSHA256(key string, b []byte) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write(b)
 
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
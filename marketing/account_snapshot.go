@@ -0,0 +1,324 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+const (
+	snapshotCampaignLimit = 25
+	snapshotAdsetLimit    = 50
+	snapshotAdLimit       = 50
+)
+
+// snapshotAdsetFields and snapshotAdFields are the default fields of adsets and ads in an AccountSnapshot.
+var (
+	snapshotAdsetFields = []string{"id", "name", "status", "effective_status", "daily_budget", "lifetime_budget", "optimization_goal", "start_time", "end_time"}
+	snapshotAdFields    = []string{"id", "name", "status"}
+)
+
+// SnapshotOptions configures LoadAccountSnapshot. The ids and parent ids are always loaded.
+type SnapshotOptions struct {
+	CampaignFields []string
+	AdsetFields    []string
+	AdFields       []string
+	CreativeFields []string
+	// Paged skips the nested field expansion and lists campaigns, adsets and ads of the account separately.
+	Paged bool
+}
+
+// CampaignNode is a campaign in an AccountSnapshot.
+type CampaignNode struct {
+	Campaign *Campaign
+	Adsets   []*AdsetNode
+}
+
+// AdsetNode is an adset in an AccountSnapshot, Parent is nil if its campaign is not part of the snapshot.
+type AdsetNode struct {
+	Adset  *Adset
+	Parent *CampaignNode
+	Ads    []*AdNode
+}
+
+// AdNode is an ad in an AccountSnapshot, Parent and Creative are nil if they are not part of the snapshot.
+type AdNode struct {
+	Ad       *Ad
+	Parent   *AdsetNode
+	Creative *CreativeNode
+}
+
+// CreativeNode is a creative in an AccountSnapshot together with the ads using it.
+type CreativeNode struct {
+	Creative *AdCreative
+	Ads      []*AdNode
+}
+
+// AccountSnapshot is the campaign, adset, ad and creative hierarchy of an account.
+type AccountSnapshot struct {
+	AccountID string
+	Campaigns []*CampaignNode
+
+	campaigns map[string]*CampaignNode
+	adsets    map[string]*AdsetNode
+	ads       map[string]*AdNode
+	creatives map[string]*CreativeNode
+	orphans   SnapshotOrphans
+}
+
+// SnapshotOrphans are the objects of an AccountSnapshot whose parent is not part of it.
+type SnapshotOrphans struct {
+	Adsets []*AdsetNode
+	Ads    []*AdNode
+	// Creatives are used by ads of the snapshot but could not be loaded.
+	Creatives []string
+}
+
+// Empty returns true if there are no orphans.
+func (so SnapshotOrphans) Empty() bool {
+	return len(so.Adsets) == 0 && len(so.Ads) == 0 && len(so.Creatives) == 0
+}
+
+// NewAccountSnapshot links the objects to a hierarchy by their parent ids. Creatives of ads that are
+// not in creatives are orphans. If creatives is nil, the creatives of the ads are used instead.
+func NewAccountSnapshot(act string, campaigns []Campaign, adsets []Adset, ads []Ad, creatives []AdCreative) *AccountSnapshot {
+	s := &AccountSnapshot{
+		AccountID: act,
+		campaigns: map[string]*CampaignNode{},
+		adsets:    map[string]*AdsetNode{},
+		ads:       map[string]*AdNode{},
+		creatives: map[string]*CreativeNode{},
+	}
+
+	for i := range campaigns {
+		n := &CampaignNode{Campaign: &campaigns[i]}
+		s.campaigns[n.Campaign.ID] = n
+		s.Campaigns = append(s.Campaigns, n)
+	}
+	for i := range adsets {
+		n := &AdsetNode{Adset: &adsets[i], Parent: s.campaigns[adsets[i].CampaignID]}
+		s.adsets[n.Adset.ID] = n
+		if n.Parent == nil {
+			s.orphans.Adsets = append(s.orphans.Adsets, n)
+			continue
+		}
+		n.Parent.Adsets = append(n.Parent.Adsets, n)
+	}
+	for i := range creatives {
+		s.creatives[creatives[i].ID] = &CreativeNode{Creative: &creatives[i]}
+	}
+	for i := range ads {
+		a := &ads[i]
+		n := &AdNode{Ad: a, Parent: s.adsets[adParentID(a)]}
+		s.ads[a.ID] = n
+		if n.Parent == nil {
+			s.orphans.Ads = append(s.orphans.Ads, n)
+		} else {
+			n.Parent.Ads = append(n.Parent.Ads, n)
+		}
+
+		if a.Creative == nil || a.Creative.ID == "" {
+			continue
+		}
+		n.Creative = s.creatives[a.Creative.ID]
+		if n.Creative == nil && creatives != nil {
+			s.orphans.Creatives = append(s.orphans.Creatives, a.Creative.ID)
+			continue
+		} else if n.Creative == nil {
+			n.Creative = &CreativeNode{Creative: a.Creative}
+			s.creatives[a.Creative.ID] = n.Creative
+		}
+		n.Creative.Ads = append(n.Creative.Ads, n)
+	}
+
+	return s
+}
+
+// adParentID returns the adset id of an ad.
+func adParentID(a *Ad) string {
+	if a.AdsetID == "" && a.Adset != nil {
+		return a.Adset.ID
+	}
+
+	return a.AdsetID
+}
+
+// Campaign returns the campaign with the id or nil.
+func (s *AccountSnapshot) Campaign(id string) *CampaignNode {
+	return s.campaigns[id]
+}
+
+// Adset returns the adset with the id or nil.
+func (s *AccountSnapshot) Adset(id string) *AdsetNode {
+	return s.adsets[id]
+}
+
+// Ad returns the ad with the id or nil.
+func (s *AccountSnapshot) Ad(id string) *AdNode {
+	return s.ads[id]
+}
+
+// Creative returns the creative with the id or nil.
+func (s *AccountSnapshot) Creative(id string) *CreativeNode {
+	return s.creatives[id]
+}
+
+// Orphans returns the objects whose parent is not part of the snapshot.
+func (s *AccountSnapshot) Orphans() SnapshotOrphans {
+	return s.orphans
+}
+
+// LoadAccountSnapshot loads the campaigns of an account with their adsets, ads and creatives.
+// Unless opts.Paged is set, the hierarchy is loaded with nested field expansion and falls
+// back to listing campaigns, adsets and ads separately if the response is too large.
+// The creatives are listed separately, so creatives of ads that are missing from the account are orphans.
+func (s *Service) LoadAccountSnapshot(ctx context.Context, act string, opts SnapshotOptions) (*AccountSnapshot, error) {
+	if len(opts.CampaignFields) == 0 {
+		opts.CampaignFields = campaignFieldsShort
+	}
+	if len(opts.AdsetFields) == 0 {
+		opts.AdsetFields = snapshotAdsetFields
+	}
+	if len(opts.AdFields) == 0 {
+		opts.AdFields = snapshotAdFields
+	}
+	if len(opts.CreativeFields) == 0 {
+		opts.CreativeFields = s.v.adCreativeFields()
+	}
+	opts.CampaignFields = withFields(opts.CampaignFields, "id")
+	opts.AdsetFields = withFields(opts.AdsetFields, "id", "campaign_id")
+	opts.AdFields = withFields(opts.AdFields, "id", "adset_id", "creative{id}")
+	opts.CreativeFields = withFields(opts.CreativeFields, "id")
+
+	if !opts.Paged {
+		snapshot, err := s.loadNestedSnapshot(ctx, act, opts)
+		if err == nil || !fb.IsReduceData(err) {
+			return snapshot, err
+		}
+	}
+
+	return s.loadPagedSnapshot(ctx, act, opts)
+}
+
+type nestedAdsets struct {
+	Data []nestedAdset `json:"data"`
+	fb.Paging
+}
+
+type nestedAdset struct {
+	Adset
+	Ads *nestedAds `json:"ads,omitempty"`
+}
+
+type nestedAds struct {
+	Data []Ad `json:"data"`
+	fb.Paging
+}
+
+type nestedCampaign struct {
+	Campaign
+	Adsets *nestedAdsets `json:"adsets,omitempty"`
+}
+
+// loadNestedSnapshot requests the adsets and ads as nested fields of the campaigns and lists further pages of nested edges.
+func (s *Service) loadNestedSnapshot(ctx context.Context, act string, opts SnapshotOptions) (*AccountSnapshot, error) {
+	adFields := fmt.Sprintf("ads.limit(%d){%s}", snapshotAdLimit, strings.Join(opts.AdFields, ","))
+	adsetFields := fmt.Sprintf("adsets.limit(%d){%s}", snapshotAdsetLimit, strings.Join(append(append([]string{}, opts.AdsetFields...), adFields), ","))
+	nested := []nestedCampaign{}
+	err := s.Client.GetList(ctx, fb.NewRoute(s.v.Name, "/act_%s/campaigns", act).
+		Fields(append(append([]string{}, opts.CampaignFields...), adsetFields)...).
+		Limit(snapshotCampaignLimit).
+		String(), &nested)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns, adsets, ads := []Campaign{}, []Adset{}, []Ad{}
+	for _, nc := range nested {
+		campaigns = append(campaigns, nc.Campaign)
+		if nc.Adsets == nil {
+			continue
+		}
+		nestedSets := nc.Adsets.Data
+		if next := nc.Adsets.Paging.Paging.Next; next != "" {
+			err = s.Client.GetList(ctx, next, &nestedSets)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, na := range nestedSets {
+			adsets = append(adsets, na.Adset)
+			if na.Ads == nil {
+				continue
+			}
+			ads = append(ads, na.Ads.Data...)
+			if next := na.Ads.Paging.Paging.Next; next != "" {
+				err = s.Client.GetList(ctx, next, &ads)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	creatives, err := s.loadSnapshotCreatives(ctx, act, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountSnapshot(act, campaigns, adsets, ads, creatives), nil
+}
+
+// loadPagedSnapshot lists the campaigns, adsets and ads of the account separately.
+func (s *Service) loadPagedSnapshot(ctx context.Context, act string, opts SnapshotOptions) (*AccountSnapshot, error) {
+	campaigns, err := (&CampaignListCall{
+		RouteBuilder: fb.NewRoute(s.v.Name, "/act_%s/campaigns", act).Fields(opts.CampaignFields...).Limit(1000),
+		c:            s.Client,
+	}).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	adsets, err := (&AdsetService{s.Client, s.v}).List(act, opts.AdsetFields).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ads, err := (&AdListCall{
+		RouteBuilder: fb.NewRoute(s.v.Name, "/act_%s/ads", act).Fields(opts.AdFields...).Limit(adCreativeReadListLimit),
+		c:            s.Client,
+	}).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	creatives, err := s.loadSnapshotCreatives(ctx, act, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountSnapshot(act, campaigns, adsets, ads, creatives), nil
+}
+
+// loadSnapshotCreatives lists the creatives of the account.
+func (s *Service) loadSnapshotCreatives(ctx context.Context, act string, opts SnapshotOptions) ([]AdCreative, error) {
+	return (&AdCreativeListCall{
+		RouteBuilder: fb.NewRoute(s.v.Name, "/act_%s/adcreatives", act).Fields(opts.CreativeFields...).Limit(adCreativeReadListLimit),
+		c:            s.Client,
+	}).Do(ctx)
+}
+
+// withFields returns the fields with the required fields added if they are missing.
+func withFields(fields []string, required ...string) []string {
+	res := append([]string{}, fields...)
+	for _, r := range required {
+		found := false
+		for _, f := range fields {
+			found = found || f == r
+		}
+		if !found {
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
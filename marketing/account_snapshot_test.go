@@ -0,0 +1,86 @@
+package marketing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestLoadAccountSnapshot(t *testing.T) {
+	reduce := false
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: accountSnapshotRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, ""
+		fields := request.URL.Query().Get("fields")
+		switch request.URL.Path {
+		case "/v24.0/act_1/campaigns":
+			if !strings.Contains(fields, "adsets.limit(") {
+				body = `{"data":[{"id":"c1"}]}`
+			} else if reduce {
+				status, body = http.StatusInternalServerError, `{"error":{"message":"Please reduce the amount of data you're asking for, then retry your request","code":1}}`
+			} else {
+				body = `{"data":[{"id":"c1","adsets":{"data":[{"id":"as1","campaign_id":"c1","ads":{"data":[{"id":"ad1","adset_id":"as1","creative":{"id":"cr1"}}],` +
+					`"paging":{"next":"https://graph.facebook.com/v24.0/as1/ads?after=x"}}}]}}]}`
+			}
+		case "/v24.0/as1/ads":
+			body = `{"data":[{"id":"ad2","adset_id":"as1","creative":{"id":"cr1"}}]}`
+		case "/v24.0/act_1/adsets":
+			body = `{"data":[{"id":"as1","campaign_id":"c1"},{"id":"as2","campaign_id":"c2"}]}`
+		case "/v24.0/act_1/ads":
+			if !strings.Contains(fields, "creative{id}") || !strings.Contains(fields, "adset_id") {
+				t.Fatalf("unexpected ad fields %s", fields)
+			}
+			body = `{"data":[{"id":"ad1","adset_id":"as1","creative":{"id":"cr1"}},{"id":"ad3","adset_id":"as2","creative":{"id":"cr9"}}]}`
+		case "/v24.0/act_1/adcreatives":
+			body = `{"data":[{"id":"cr1","name":"Creative"}]}`
+		default:
+			t.Fatalf("unexpected request %s", request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &Service{Client: client, v: Version{Name: "v24.0"}}
+
+	s, err := service.LoadAccountSnapshot(context.Background(), "1", SnapshotOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Campaigns) != 1 || len(s.Campaign("c1").Adsets) != 1 || len(s.Adset("as1").Ads) != 2 {
+		t.Fatalf("unexpected hierarchy %+v", s)
+	}
+	if cr := s.Creative("cr1"); cr == nil || cr.Creative.Name != "Creative" || len(cr.Ads) != 2 || s.Ad("ad2").Creative != cr || s.Ad("ad2").Parent.Parent != s.Campaign("c1") {
+		t.Fatal("creative not linked")
+	}
+	if !s.Orphans().Empty() {
+		t.Fatalf("unexpected orphans %+v", s.Orphans())
+	}
+
+	reduce = true
+	s, err = service.LoadAccountSnapshot(context.Background(), "1", SnapshotOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := s.Orphans()
+	if len(o.Adsets) != 1 || o.Adsets[0].Adset.ID != "as2" || len(o.Ads) != 0 || s.Ad("ad3").Parent != s.Adset("as2") {
+		t.Fatalf("unexpected orphans %+v", o)
+	}
+	if len(o.Creatives) != 1 || o.Creatives[0] != "cr9" || s.Ad("ad3").Creative != nil {
+		t.Fatalf("unexpected creative orphans %v", o.Creatives)
+	}
+}
+
+type accountSnapshotRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f accountSnapshotRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,355 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// Categories of activities.
+const (
+	ActivityCategoryAccount   = "ACCOUNT"
+	ActivityCategoryAd        = "AD"
+	ActivityCategoryAdset     = "AD_SET"
+	ActivityCategoryAudience  = "AUDIENCE"
+	ActivityCategoryBid       = "BID"
+	ActivityCategoryBudget    = "BUDGET"
+	ActivityCategoryCampaign  = "CAMPAIGN"
+	ActivityCategoryDate      = "DATE"
+	ActivityCategoryStatus    = "STATUS"
+	ActivityCategoryTargeting = "TARGETING"
+)
+
+// Event types of activities with typed extra data.
+const (
+	ActivityEventCampaignBudget    = "update_campaign_budget"
+	ActivityEventAdsetBudget       = "update_ad_set_budget"
+	ActivityEventCampaignRunStatus = "update_campaign_run_status"
+	ActivityEventAdsetRunStatus    = "update_ad_set_run_status"
+	ActivityEventAdRunStatus       = "update_ad_run_status"
+	ActivityEventCampaignName      = "update_campaign_name"
+	ActivityEventAdsetName         = "update_ad_set_name"
+	ActivityEventAdsetTargeting    = "update_ad_set_target_spec"
+	ActivityEventAdsetBidStrategy  = "update_ad_set_bid_strategy"
+)
+
+const activityListLimit = 500
+
+var activityFields = []string{
+	"actor_id",
+	"actor_name",
+	"application_id",
+	"application_name",
+	"date_time_in_timezone",
+	"event_time",
+	"event_type",
+	"extra_data",
+	"object_id",
+	"object_name",
+	"object_type",
+	"translated_event_type",
+}
+
+// ActivityService reads the activity log of ad accounts.
+type ActivityService struct {
+	c *fb.Client
+	v Version
+}
+
+// Activity https://developers.facebook.com/docs/marketing-api/reference/ad-activity/
+type Activity struct {
+	ActorID             string  `json:"actor_id,omitempty"`
+	ActorName           string  `json:"actor_name,omitempty"`
+	ApplicationID       string  `json:"application_id,omitempty"`
+	ApplicationName     string  `json:"application_name,omitempty"`
+	DateTimeInTimezone  string  `json:"date_time_in_timezone,omitempty"`
+	EventTime           fb.Time `json:"event_time"`
+	EventType           string  `json:"event_type,omitempty"`
+	ExtraData           string  `json:"extra_data,omitempty"`
+	ObjectID            string  `json:"object_id,omitempty"`
+	ObjectName          string  `json:"object_name,omitempty"`
+	ObjectType          string  `json:"object_type,omitempty"`
+	TranslatedEventType string  `json:"translated_event_type,omitempty"`
+}
+
+// key identifies an activity, activities don't have an id.
+func (a Activity) key() string {
+	return fmt.Sprintf("%d/%s/%s/%s/%s", time.Time(a.EventTime).Unix(), a.EventType, a.ObjectID, a.ActorID, a.ExtraData)
+}
+
+// ActivityBudget is the value of a budget change.
+type ActivityBudget struct {
+	Type     string  `json:"type,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	Amount   float64 `json:"amount"`
+}
+
+// UnmarshalJSON accepts plain amounts and objects with the amount in value, old_value or new_value.
+func (ab *ActivityBudget) UnmarshalJSON(b []byte) error {
+	var amount json.Number
+	if json.Unmarshal(b, &amount) == nil {
+		f, err := amount.Float64()
+		ab.Amount = f
+
+		return err
+	}
+
+	v := struct {
+		Type     string      `json:"type"`
+		Currency string      `json:"currency"`
+		Value    json.Number `json:"value"`
+		OldValue json.Number `json:"old_value"`
+		NewValue json.Number `json:"new_value"`
+	}{}
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+	ab.Type, ab.Currency = v.Type, v.Currency
+	for _, n := range []json.Number{v.Value, v.OldValue, v.NewValue} {
+		if n != "" {
+			ab.Amount, err = n.Float64()
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ActivityChange contains the old and new value of an activity. The values are *ActivityBudget for budget events,
+// strings for string values like statuses and names, and json.RawMessage otherwise.
+type ActivityChange struct {
+	Old interface{}
+	New interface{}
+}
+
+// Change decodes the extra data of the activity. It returns nil if the activity has no old and new value.
+func (a Activity) Change() (*ActivityChange, error) {
+	if a.ExtraData == "" {
+		return nil, nil
+	}
+	raw := struct {
+		Old json.RawMessage `json:"old_value"`
+		New json.RawMessage `json:"new_value"`
+	}{}
+	err := json.Unmarshal([]byte(a.ExtraData), &raw)
+	if err != nil {
+		return nil, fmt.Errorf("decoding extra data of %s: %w", a.EventType, err)
+	} else if raw.Old == nil && raw.New == nil {
+		return nil, nil
+	}
+
+	ac := &ActivityChange{}
+	ac.Old, err = activityValue(a.EventType, raw.Old)
+	if err != nil {
+		return nil, err
+	}
+	ac.New, err = activityValue(a.EventType, raw.New)
+	if err != nil {
+		return nil, err
+	}
+
+	return ac, nil
+}
+
+func activityValue(eventType string, raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	switch eventType {
+	case ActivityEventCampaignBudget, ActivityEventAdsetBudget:
+		ab := &ActivityBudget{}
+		err := json.Unmarshal(raw, ab)
+		if err != nil {
+			return nil, fmt.Errorf("decoding budget of %s: %w", eventType, err)
+		}
+
+		return ab, nil
+	}
+	s := ""
+	if json.Unmarshal(raw, &s) == nil {
+		return s, nil
+	}
+
+	return raw, nil
+}
+
+// List returns an ActivityListCall for all activities of an account, newest first.
+func (as *ActivityService) List(act string) *ActivityListCall {
+	return &ActivityListCall{
+		RouteBuilder: fb.NewRoute(as.v.Name, "/act_%s/activities", act).Fields(activityFields...).Limit(activityListLimit),
+		c:            as.c,
+	}
+}
+
+// ActivityListCall is used for listing activities, use Since, Until and Category of the RouteBuilder for filtering.
+type ActivityListCall struct {
+	*fb.RouteBuilder
+	c *fb.Client
+}
+
+// Do calls the graph API.
+func (alc *ActivityListCall) Do(ctx context.Context) ([]Activity, error) {
+	res := []Activity{}
+	err := alc.c.GetList(ctx, alc.RouteBuilder.String(), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Iterator returns an ActivityIterator reading the activities page by page.
+func (alc *ActivityListCall) Iterator() *ActivityIterator {
+	return &ActivityIterator{c: alc.c, next: alc.RouteBuilder.String()}
+}
+
+// ActivityIterator streams activities, pages are requested when needed.
+type ActivityIterator struct {
+	c       *fb.Client
+	next    string
+	page    []Activity
+	current Activity
+	err     error
+}
+
+// Next advances to the next activity and returns false when there are no more activities or an error occurred.
+func (it *ActivityIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.next == "" || it.err != nil {
+			return false
+		}
+		res := &struct {
+			Data []Activity `json:"data"`
+			fb.Paging
+		}{}
+		it.err = it.c.GetJSON(ctx, it.next, res)
+		if it.err != nil {
+			return false
+		}
+		it.page, it.next = res.Data, res.Paging.Paging.Next
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Activity returns the current activity.
+func (it *ActivityIterator) Activity() Activity {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration.
+func (it *ActivityIterator) Err() error {
+	return it.err
+}
+
+// ActivityCheckpoint is the position of the last polled activity.
+type ActivityCheckpoint struct {
+	Time time.Time `json:"time"`
+	// Seen are the keys of the activities at Time, since is inclusive.
+	Seen []string `json:"seen,omitempty"`
+}
+
+// ActivityCheckpointStore persists ActivityCheckpoints by key. Load returns nil if there is no checkpoint for key.
+type ActivityCheckpointStore interface {
+	Load(key string) (*ActivityCheckpoint, error)
+	Save(key string, cp *ActivityCheckpoint) error
+}
+
+// FileActivityCheckpointStore stores each ActivityCheckpoint as JSON file in Dir.
+type FileActivityCheckpointStore struct {
+	Dir string
+}
+
+// Load implements ActivityCheckpointStore.
+func (fs FileActivityCheckpointStore) Load(key string) (*ActivityCheckpoint, error) {
+	b, err := os.ReadFile(storePath(fs.Dir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	cp := &ActivityCheckpoint{}
+	err = json.Unmarshal(b, cp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// Save implements ActivityCheckpointStore, the file is replaced atomically.
+func (fs FileActivityCheckpointStore) Save(key string, cp *ActivityCheckpoint) error {
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(storePath(fs.Dir, key), b)
+}
+
+// Poll returns the activities of the account in the category since the stored checkpoint, oldest first,
+// and stores the new checkpoint. All activities are returned if there is no checkpoint yet.
+// The category is optional.
+func (as *ActivityService) Poll(ctx context.Context, act, category string, store ActivityCheckpointStore) ([]Activity, error) {
+	key := "activities_" + act
+	if category != "" {
+		key += "_" + category
+	}
+	cp, err := store.Load(key)
+	if err != nil {
+		return nil, err
+	} else if cp == nil {
+		cp = &ActivityCheckpoint{}
+	}
+	seen := map[string]bool{}
+	for _, k := range cp.Seen {
+		seen[k] = true
+	}
+
+	lc := as.List(act)
+	lc.Since(cp.Time).Category(category)
+	activities, err := lc.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Activity{}
+	for _, a := range activities {
+		if !seen[a.key()] && !time.Time(a.EventTime).Before(cp.Time) {
+			res = append(res, a)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return time.Time(res[i].EventTime).Before(time.Time(res[j].EventTime))
+	})
+	if len(res) == 0 {
+		return res, nil
+	}
+
+	last := time.Time(res[len(res)-1].EventTime)
+	next := &ActivityCheckpoint{Time: last}
+	if last.Equal(cp.Time) {
+		next.Seen = cp.Seen
+	}
+	for _, a := range res {
+		if time.Time(a.EventTime).Equal(last) {
+			next.Seen = append(next.Seen, a.key())
+		}
+	}
+	err = store.Save(key, next)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,100 @@
+package marketing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestActivityChange(t *testing.T) {
+	a := Activity{
+		EventType: ActivityEventAdsetBudget,
+		ExtraData: `{"old_value":{"type":"daily_budget","old_value":5000,"currency":"EUR"},"new_value":{"type":"daily_budget","new_value":"7500","currency":"EUR"},"type":"payment_amount"}`,
+	}
+	c, err := a.Change()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o, n := c.Old.(*ActivityBudget), c.New.(*ActivityBudget); o.Amount != 5000 || n.Amount != 7500 || n.Currency != "EUR" || n.Type != "daily_budget" {
+		t.Fatalf("unexpected budgets %+v %+v", o, n)
+	}
+
+	a = Activity{EventType: ActivityEventAdRunStatus, ExtraData: `{"old_value":"Active","new_value":"Paused"}`}
+	c, err = a.Change()
+	if err != nil || c.Old != "Active" || c.New != "Paused" {
+		t.Fatalf("unexpected change %+v %v", c, err)
+	}
+}
+
+func TestActivityPoll(t *testing.T) {
+	pages := map[string]string{
+		"": `{"data":[{"event_time":"2026-01-02T10:00:00+0000","event_type":"update_ad_run_status","object_id":"3"},` +
+			`{"event_time":"2026-01-02T10:00:00+0000","event_type":"update_ad_run_status","object_id":"2"}],` +
+			`"paging":{"next":"https://graph.facebook.com/v24.0/act_1/activities?after=p2"}}`,
+		"p2": `{"data":[{"event_time":"2026-01-01T10:00:00+0000","event_type":"update_campaign_name","object_id":"1"}]}`,
+	}
+	sinces, category := []string{}, ActivityCategoryStatus
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: activityRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if request.URL.Path != "/v24.0/act_1/activities" {
+			t.Fatalf("unexpected request %s", request.URL)
+		}
+		q := request.URL.Query()
+		if q.Get("after") == "" {
+			sinces = append(sinces, q.Get("since"))
+		}
+		if q.Get("category") != category && q.Get("after") == "" {
+			t.Fatalf("missing category %s", request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(pages[q.Get("after")])),
+			Request:    request,
+		}, nil
+	})}
+	service := &ActivityService{c: client, v: Version{Name: "v24.0"}}
+	store := FileActivityCheckpointStore{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	res, err := service.Poll(ctx, "1", ActivityCategoryStatus, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 3 || res[0].ObjectID != "1" {
+		t.Fatalf("unexpected activities %+v", res)
+	}
+
+	res, err = service.Poll(ctx, "1", ActivityCategoryStatus, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 0 {
+		t.Fatalf("polled %d activities again", len(res))
+	}
+	if sinces[0] != "" || sinces[1] != "1767348000" {
+		t.Fatalf("unexpected since params %v", sinces)
+	}
+
+	category = ""
+	it := service.List("1").Iterator()
+	count := 0
+	for it.Next(ctx) {
+		count++
+	}
+	if it.Err() != nil || count != 3 {
+		t.Fatalf("iterated %d activities: %v", count, it.Err())
+	}
+}
+
+type activityRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f activityRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -9,12 +9,13 @@ import (
 // AdAccountService works with ad accounts.
 type AdAccountService struct {
 	c *fb.Client
+	v Version
 }
 
 // List lists all ad accounts that belong to this business.
 func (aas *AdAccountService) List(ctx context.Context, businessID string) ([]AdAccount, error) {
 	res := []AdAccount{}
-	rb := fb.NewRoute(Version, "/%s/owned_ad_accounts", businessID).Limit(1000).Fields("name", "currency", "account_id", "timezone_name")
+	rb := fb.NewRoute(aas.v.Name, "/%s/owned_ad_accounts", businessID).Limit(1000).Fields("name", "currency", "account_id", "timezone_name")
 	err := aas.c.GetList(ctx, rb.String(), &res)
 	if err != nil {
 		return nil, err
@@ -40,7 +41,7 @@ type TargetingValidationResult struct {
 // ValidateTargeting validates a list of targeting IDs against the ad account's
 // targetingvalidation endpoint. Returns the IDs that are valid and those that are invalid.
 func (aas *AdAccountService) ValidateTargeting(ctx context.Context, adAccountID string, ids []string) (validIDs, invalidIDs []string, err error) {
-	rb := fb.NewRoute(Version, "/act_%s/targetingvalidation", adAccountID).
+	rb := fb.NewRoute(aas.v.Name, "/act_%s/targetingvalidation", adAccountID).
 		IDList(ids...).
 		Limit(len(ids))
 
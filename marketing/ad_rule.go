@@ -0,0 +1,401 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// Statuses of ad rules.
+const (
+	AdRuleEnabled  = "ENABLED"
+	AdRuleDisabled = "DISABLED"
+)
+
+// Evaluation types of ad rules.
+const (
+	AdRuleEvaluationSchedule = "SCHEDULE"
+	AdRuleEvaluationTrigger  = "TRIGGER"
+)
+
+// Execution types of ad rules.
+const (
+	AdRuleExecutionPause                = "PAUSE"
+	AdRuleExecutionUnpause              = "UNPAUSE"
+	AdRuleExecutionChangeBudget         = "CHANGE_BUDGET"
+	AdRuleExecutionChangeBid            = "CHANGE_BID"
+	AdRuleExecutionNotification         = "NOTIFICATION"
+	AdRuleExecutionPingEndpoint         = "PING_ENDPOINT"
+	AdRuleExecutionRebalance            = "REBALANCE_BUDGET"
+	AdRuleExecutionRotate               = "ROTATE"
+	AdRuleExecutionChangeCampaignBudget = "CHANGE_CAMPAIGN_BUDGET"
+)
+
+// Operators of ad rule filters and triggers.
+const (
+	AdRuleGreaterThan = "GREATER_THAN"
+	AdRuleLessThan    = "LESS_THAN"
+	AdRuleEqual       = "EQUAL"
+	AdRuleNotEqual    = "NOT_EQUAL"
+	AdRuleInRange     = "IN_RANGE"
+	AdRuleNotInRange  = "NOT_IN_RANGE"
+	AdRuleIn          = "IN"
+	AdRuleNotIn       = "NOT_IN"
+	AdRuleContain     = "CONTAIN"
+	AdRuleNotContain  = "NOT_CONTAIN"
+)
+
+// Units of budget changes.
+const (
+	AdRuleUnitPercentage      = "PERCENTAGE"
+	AdRuleUnitAccountCurrency = "ACCOUNT_CURRENCY"
+)
+
+// Schedule types of ad rules.
+const (
+	AdRuleScheduleDaily      = "DAILY"
+	AdRuleScheduleHourly     = "HOURLY"
+	AdRuleScheduleSemiHourly = "SEMI_HOURLY"
+	AdRuleScheduleCustom     = "CUSTOM"
+)
+
+var adRuleFields = []string{
+	"id",
+	"account_id",
+	"name",
+	"status",
+	"evaluation_spec",
+	"execution_spec",
+	"schedule_spec",
+	"created_by",
+	"created_time",
+	"updated_time",
+}
+
+// AdRuleService works on the automated rules of ad accounts.
+type AdRuleService struct {
+	c *fb.Client
+	v Version
+}
+
+// AdRule https://developers.facebook.com/docs/marketing-api/reference/ad-rule/
+type AdRule struct {
+	ID             string                `json:"id,omitempty"`
+	AccountID      string                `json:"account_id,omitempty"`
+	Name           string                `json:"name,omitempty"`
+	Status         string                `json:"status,omitempty"`
+	EvaluationSpec *AdRuleEvaluationSpec `json:"evaluation_spec,omitempty"`
+	ExecutionSpec  *AdRuleExecutionSpec  `json:"execution_spec,omitempty"`
+	ScheduleSpec   *AdRuleScheduleSpec   `json:"schedule_spec,omitempty"`
+
+	// read only fields
+	CreatedBy   *IDContainer `json:"created_by,omitempty"`
+	CreatedTime fb.Time      `json:"created_time,omitzero"`
+	UpdatedTime fb.Time      `json:"updated_time,omitzero"`
+}
+
+// AdRuleEvaluationSpec defines the objects an ad rule applies to.
+type AdRuleEvaluationSpec struct {
+	EvaluationType string         `json:"evaluation_type"`
+	Filters        []AdRuleFilter `json:"filters,omitempty"`
+	Trigger        *AdRuleTrigger `json:"trigger,omitempty"`
+	ID             string         `json:"id,omitempty"`
+}
+
+// AdRuleFilter filters the objects of an ad rule by a field, e.g. entity_type, time_preset or a metric like spent.
+type AdRuleFilter struct {
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// AdRuleTrigger is the change triggering a rule with TRIGGER evaluation.
+type AdRuleTrigger struct {
+	Type     string      `json:"type"`
+	Field    string      `json:"field,omitempty"`
+	Operator string      `json:"operator,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+}
+
+// AdRuleExecutionSpec defines the action of an ad rule.
+type AdRuleExecutionSpec struct {
+	ExecutionType    string                  `json:"execution_type"`
+	ExecutionOptions []AdRuleExecutionOption `json:"execution_options,omitempty"`
+	ID               string                  `json:"id,omitempty"`
+}
+
+// AdRuleExecutionOption configures the action, e.g. user_ids of notifications or the change_spec of budget changes.
+type AdRuleExecutionOption struct {
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// AdRuleChangeSpec is the value of the change_spec option of budget and bid changes.
+// Amount is negative for decreases.
+type AdRuleChangeSpec struct {
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+	Limit  float64 `json:"limit,omitempty"`
+}
+
+// AdRuleScheduleSpec defines when a rule with SCHEDULE evaluation is evaluated.
+type AdRuleScheduleSpec struct {
+	ScheduleType string           `json:"schedule_type"`
+	Schedule     []AdRuleSchedule `json:"schedule,omitempty"`
+	ID           string           `json:"id,omitempty"`
+}
+
+// AdRuleSchedule is a custom schedule, the minutes are relative to midnight and days are 0 for sunday to 6.
+type AdRuleSchedule struct {
+	StartMinute int   `json:"start_minute,omitempty"`
+	EndMinute   int   `json:"end_minute,omitempty"`
+	Days        []int `json:"days,omitempty"`
+}
+
+// EntityTypeFilter filters the objects by their type, one of CAMPAIGN, ADSET or AD.
+func EntityTypeFilter(entityType string) AdRuleFilter {
+	return AdRuleFilter{Field: "entity_type", Operator: AdRuleEqual, Value: entityType}
+}
+
+// IDFilter limits a rule to the objects with the ids.
+func IDFilter(ids ...string) AdRuleFilter {
+	return AdRuleFilter{Field: "id", Operator: AdRuleIn, Value: ids}
+}
+
+// TimePresetFilter sets the time range of the metrics a rule evaluates, e.g. LAST_7_DAYS or MAXIMUM.
+func TimePresetFilter(preset string) AdRuleFilter {
+	return AdRuleFilter{Field: "time_preset", Operator: AdRuleEqual, Value: preset}
+}
+
+// MetricFilter compares a metric like spent, impressions or cost_per_result, values are in the smallest currency unit.
+func MetricFilter(field, operator string, value interface{}) AdRuleFilter {
+	return AdRuleFilter{Field: field, Operator: operator, Value: value}
+}
+
+// PauseExecution returns an execution spec pausing the matching objects.
+func PauseExecution() *AdRuleExecutionSpec {
+	return &AdRuleExecutionSpec{ExecutionType: AdRuleExecutionPause}
+}
+
+// ChangeBudgetExecution returns an execution spec changing the budget of the matching adsets by amount in unit.
+// The budget is not changed beyond limit if it is set.
+func ChangeBudgetExecution(amount float64, unit string, limit float64) *AdRuleExecutionSpec {
+	return &AdRuleExecutionSpec{
+		ExecutionType: AdRuleExecutionChangeBudget,
+		ExecutionOptions: []AdRuleExecutionOption{{
+			Field:    "change_spec",
+			Operator: AdRuleEqual,
+			Value:    AdRuleChangeSpec{Amount: amount, Unit: unit, Limit: limit},
+		}},
+	}
+}
+
+// NotificationExecution returns an execution spec notifying the users about the matching objects.
+func NotificationExecution(userIDs ...string) *AdRuleExecutionSpec {
+	return &AdRuleExecutionSpec{
+		ExecutionType: AdRuleExecutionNotification,
+		ExecutionOptions: []AdRuleExecutionOption{{
+			Field:    "user_ids",
+			Operator: AdRuleEqual,
+			Value:    userIDs,
+		}},
+	}
+}
+
+// Validate checks the parts of the rule that can be checked without the API.
+func (r AdRule) Validate() error {
+	if r.Name == "" {
+		return errors.New("missing name")
+	} else if r.EvaluationSpec == nil {
+		return errors.New("missing evaluation spec")
+	} else if r.ExecutionSpec == nil {
+		return errors.New("missing execution spec")
+	}
+
+	switch r.EvaluationSpec.EvaluationType {
+	case AdRuleEvaluationSchedule:
+		if r.ScheduleSpec == nil {
+			return errors.New("schedule evaluation needs a schedule spec")
+		}
+	case AdRuleEvaluationTrigger:
+		if r.EvaluationSpec.Trigger == nil {
+			return errors.New("trigger evaluation needs a trigger")
+		}
+	default:
+		return fmt.Errorf("unknown evaluation type '%s'", r.EvaluationSpec.EvaluationType)
+	}
+	hasEntityType := false
+	for _, f := range r.EvaluationSpec.Filters {
+		if f.Field == "" || f.Operator == "" {
+			return fmt.Errorf("filter %+v needs field and operator", f)
+		}
+		hasEntityType = hasEntityType || f.Field == "entity_type"
+	}
+	if !hasEntityType {
+		return errors.New("missing entity_type filter")
+	}
+
+	if r.ExecutionSpec.ExecutionType == "" {
+		return errors.New("missing execution type")
+	}
+	for _, o := range r.ExecutionSpec.ExecutionOptions {
+		if cs, ok := o.Value.(AdRuleChangeSpec); ok && cs.Unit != AdRuleUnitPercentage && cs.Unit != AdRuleUnitAccountCurrency {
+			return fmt.Errorf("unknown change unit '%s'", cs.Unit)
+		}
+	}
+	if r.ScheduleSpec != nil && r.ScheduleSpec.ScheduleType == AdRuleScheduleCustom && len(r.ScheduleSpec.Schedule) == 0 {
+		return errors.New("custom schedule needs a schedule")
+	}
+
+	return nil
+}
+
+// Get returns a single ad rule.
+func (ars *AdRuleService) Get(ctx context.Context, id string) (*AdRule, error) {
+	res := &AdRule{}
+	err := ars.c.GetJSON(ctx, fb.NewRoute(ars.v.Name, "/%s", id).Fields(adRuleFields...).String(), res)
+	if err != nil {
+		if fb.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// List returns all ad rules of an account.
+func (ars *AdRuleService) List(ctx context.Context, act string) ([]AdRule, error) {
+	res := []AdRule{}
+	err := ars.c.GetList(ctx, fb.NewRoute(ars.v.Name, "/act_%s/adrules_library", act).Fields(adRuleFields...).Limit(100).String(), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create validates and creates an ad rule and returns its id.
+func (ars *AdRuleService) Create(ctx context.Context, act string, r AdRule) (string, error) {
+	if r.ID != "" {
+		return "", fmt.Errorf("cannot create ad rule that already exists: %s", r.ID)
+	} else if act == "" {
+		return "", errors.New("cannot create ad rule without account id")
+	}
+	err := r.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	res := &fb.MinimalResponse{}
+	err = ars.c.PostJSON(ctx, fb.NewRoute(ars.v.Name, "/act_%s/adrules_library", act).String(), adRuleRequest(r), res)
+	if err != nil {
+		return "", err
+	} else if err = res.GetError(); err != nil {
+		return "", err
+	} else if res.ID == "" {
+		return "", fmt.Errorf("creating ad rule failed")
+	}
+
+	return res.ID, nil
+}
+
+// Update validates and updates an ad rule.
+func (ars *AdRuleService) Update(ctx context.Context, r AdRule) error {
+	if r.ID == "" {
+		return errors.New("cannot update ad rule without id")
+	}
+	err := r.Validate()
+	if err != nil {
+		return err
+	}
+
+	res := &fb.MinimalResponse{}
+	err = ars.c.PostJSON(ctx, fb.NewRoute(ars.v.Name, "/%s", r.ID).String(), adRuleRequest(r), res)
+	if err != nil {
+		return err
+	} else if err = res.GetError(); err != nil {
+		return err
+	} else if !res.Success && res.ID == "" {
+		return fmt.Errorf("updating the ad rule failed")
+	}
+
+	return nil
+}
+
+// Delete removes an ad rule.
+func (ars *AdRuleService) Delete(ctx context.Context, id string) error {
+	return ars.c.Delete(ctx, fb.NewRoute(ars.v.Name, "/%s", id).String())
+}
+
+// adRuleRequest contains only the writable fields of an AdRule, ids of the specs are dropped.
+func adRuleRequest(r AdRule) interface{} {
+	req := struct {
+		Name           string                `json:"name,omitempty"`
+		Status         string                `json:"status,omitempty"`
+		EvaluationSpec *AdRuleEvaluationSpec `json:"evaluation_spec,omitempty"`
+		ExecutionSpec  *AdRuleExecutionSpec  `json:"execution_spec,omitempty"`
+		ScheduleSpec   *AdRuleScheduleSpec   `json:"schedule_spec,omitempty"`
+	}{Name: r.Name, Status: r.Status}
+	if r.EvaluationSpec != nil {
+		es := *r.EvaluationSpec
+		es.ID = ""
+		req.EvaluationSpec = &es
+	}
+	if r.ExecutionSpec != nil {
+		es := *r.ExecutionSpec
+		es.ID = ""
+		req.ExecutionSpec = &es
+	}
+	if r.ScheduleSpec != nil {
+		ss := *r.ScheduleSpec
+		ss.ID = ""
+		req.ScheduleSpec = &ss
+	}
+
+	return req
+}
+
+// AdRuleHistory is a single execution of an ad rule.
+type AdRuleHistory struct {
+	EvaluationSpec   *AdRuleEvaluationSpec `json:"evaluation_spec,omitempty"`
+	ExecutionSpec    *AdRuleExecutionSpec  `json:"execution_spec,omitempty"`
+	ScheduleSpec     *AdRuleScheduleSpec   `json:"schedule_spec,omitempty"`
+	ExceptionCode    int                   `json:"exception_code,omitempty"`
+	ExceptionMessage string                `json:"exception_message,omitempty"`
+	IsManual         bool                  `json:"is_manual,omitempty"`
+	Results          []AdRuleHistoryResult `json:"results,omitempty"`
+	RuleID           string                `json:"rule_id,omitempty"`
+	Timestamp        fb.Time               `json:"timestamp"`
+}
+
+// AdRuleHistoryResult contains the actions taken on an object by an execution.
+type AdRuleHistoryResult struct {
+	EntityID   string                `json:"entity_id"`
+	EntityType string                `json:"entity_type"`
+	Actions    []AdRuleHistoryAction `json:"actions,omitempty"`
+}
+
+// AdRuleHistoryAction is a change of a single field.
+type AdRuleHistoryAction struct {
+	Action   string          `json:"action"`
+	Field    string          `json:"field,omitempty"`
+	OldValue json.RawMessage `json:"old_value,omitempty"`
+	NewValue json.RawMessage `json:"new_value,omitempty"`
+}
+
+// History returns the executions of an ad rule, newest first.
+func (ars *AdRuleService) History(ctx context.Context, id string) ([]AdRuleHistory, error) {
+	res := []AdRuleHistory{}
+	err := ars.c.GetList(ctx, fb.NewRoute(ars.v.Name, "/%s/history", id).Limit(100).String(), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,88 @@
+package marketing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestAdRuleService(t *testing.T) {
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: adRuleRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		body := ""
+		switch request.URL.Path {
+		case "/v24.0/act_1/adrules_library":
+			b, err := io.ReadAll(request.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := `{"name":"Scale winners","status":"ENABLED","evaluation_spec":{"evaluation_type":"SCHEDULE","filters":[` +
+				`{"field":"entity_type","operator":"EQUAL","value":"ADSET"},{"field":"time_preset","operator":"EQUAL","value":"LAST_3_DAYS"},` +
+				`{"field":"cost_per_result","operator":"LESS_THAN","value":500}]},` +
+				`"execution_spec":{"execution_type":"CHANGE_BUDGET","execution_options":[{"field":"change_spec","operator":"EQUAL","value":{"amount":20,"unit":"PERCENTAGE","limit":100000}}]},` +
+				`"schedule_spec":{"schedule_type":"DAILY"}}`
+			if strings.TrimSpace(string(b)) != want {
+				t.Fatalf("body = %s\nwant %s", b, want)
+			}
+			body = `{"id":"r1"}`
+		case "/v24.0/r1/history":
+			body = `{"data":[{"rule_id":"r1","timestamp":"2026-01-02T10:00:00+0000","results":[{"entity_id":"as1","entity_type":"ADSET",` +
+				`"actions":[{"action":"CHANGED_BUDGET","field":"daily_budget","old_value":"1000","new_value":"1200"}]}]}]}`
+		default:
+			t.Fatalf("unexpected request %s", request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &AdRuleService{c: client, v: Version{Name: "v24.0"}}
+	ctx := context.Background()
+
+	r := AdRule{
+		Name:   "Scale winners",
+		Status: AdRuleEnabled,
+		EvaluationSpec: &AdRuleEvaluationSpec{
+			EvaluationType: AdRuleEvaluationSchedule,
+			Filters:        []AdRuleFilter{EntityTypeFilter("ADSET"), TimePresetFilter("LAST_3_DAYS"), MetricFilter("cost_per_result", AdRuleLessThan, 500)},
+			ID:             "old",
+		},
+		ExecutionSpec: ChangeBudgetExecution(20, AdRuleUnitPercentage, 100000),
+		ScheduleSpec:  &AdRuleScheduleSpec{ScheduleType: AdRuleScheduleDaily},
+	}
+	id, err := service.Create(ctx, "1", r)
+	if err != nil || id != "r1" {
+		t.Fatalf("Create() = %s, %v", id, err)
+	}
+
+	history, err := service.History(ctx, "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].Results[0].Actions[0].Field != "daily_budget" || string(history[0].Results[0].Actions[0].NewValue) != `"1200"` {
+		t.Fatalf("unexpected history %+v", history)
+	}
+
+	r.ScheduleSpec = nil
+	if _, err := service.Create(ctx, "1", r); err == nil {
+		t.Fatal("expected error for schedule evaluation without schedule")
+	}
+	r.ScheduleSpec, r.EvaluationSpec.Filters = &AdRuleScheduleSpec{ScheduleType: AdRuleScheduleDaily}, nil
+	if err := r.Validate(); err == nil {
+		t.Fatal("expected error for missing entity type")
+	}
+}
+
+type adRuleRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f adRuleRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -19,13 +19,14 @@ const (
 // AdCreativeService works on adcreatives.
 type AdCreativeService struct {
 	c *fb.Client
+	v Version
 	*fb.StatsContainer
 }
 
 // Get return a single creative.
 func (as *AdCreativeService) Get(ctx context.Context, id string) (*AdCreative, error) {
 	res := &AdCreative{}
-	err := as.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Fields(Adcreativefields...).String(), res)
+	err := as.c.GetJSON(ctx, fb.NewRoute(as.v.Name, "/%s", id).Fields(as.v.adCreativeFields()...).String(), res)
 	if err != nil {
 		if fb.IsNotFound(err) {
 			return nil, nil
@@ -50,7 +51,7 @@ func (as *AdCreativeService) Create(ctx context.Context, a AdCreative) (string,
 		fb.ErrorContainer
 		EffectiveObjectStoryID string `json:"effective_object_story_id"`
 	}{}
-	err := as.c.PostJSON(ctx, fb.NewRoute(Version, "/act_%s/adcreatives", a.AccountID).Fields("id", "effective_object_story_id").String(), a, &res)
+	err := as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/act_%s/adcreatives", a.AccountID).Fields("id", "effective_object_story_id").String(), a, &res)
 	if err != nil {
 		return "", "", err
 	} else if err = res.GetError(); err != nil {
@@ -62,12 +63,17 @@ func (as *AdCreativeService) Create(ctx context.Context, a AdCreative) (string,
 	return res.ID.ID, res.EffectiveObjectStoryID, nil
 }
 
+// Delete deletes a creative. Creatives can't be archived, and creatives used by ads can't be deleted.
+func (as *AdCreativeService) Delete(ctx context.Context, id string) error {
+	return as.c.Delete(ctx, fb.NewRoute(as.v.Name, "/%s", id).String())
+}
+
 // GetPreviewURL returns the preview URL of a creative.
 func (as *AdCreativeService) GetPreviewURL(ctx context.Context, id, format string) (string, error) {
 	b := []struct {
 		Body string `json:"body"`
 	}{}
-	err := as.c.GetList(ctx, fb.NewRoute(Version, "/%s/previews", id).AdFormat(format).String(), &b)
+	err := as.c.GetList(ctx, fb.NewRoute(as.v.Name, "/%s/previews", id).AdFormat(format).String(), &b)
 	if err != nil {
 		return "", err
 	} else if len(b) != 1 {
@@ -94,20 +100,20 @@ type AdCreativeListCall struct {
 
 func (s *AdCreativeService) List(act string, fields []string) *AdCreativeListCall {
 	if len(fields) == 0 {
-		fields = Adcreativefields
+		fields = s.v.adCreativeFields()
 	}
 	return &AdCreativeListCall{
 		c:            s.c,
-		RouteBuilder: fb.NewRoute(Version, "/act_%s/ads", act).Limit(adCreativeReadListLimit).Fields(fmt.Sprintf("adcreatives{%v}", strings.Join(fields, ","))),
+		RouteBuilder: fb.NewRoute(s.v.Name, "/act_%s/ads", act).Limit(adCreativeReadListLimit).Fields(fmt.Sprintf("adcreatives{%v}", strings.Join(fields, ","))),
 	}
 }
 func (s *AdCreativeService) ListOfCampaign(campaignID string, fields []string) *AdCreativeListCall {
 	if len(fields) == 0 {
-		fields = Adcreativefields
+		fields = s.v.adCreativeFields()
 	}
 	return &AdCreativeListCall{
 		c:            s.c,
-		RouteBuilder: fb.NewRoute(Version, "/%s/ads", campaignID).Limit(10).Fields(fmt.Sprintf("adcreatives{%v}", strings.Join(fields, ","))),
+		RouteBuilder: fb.NewRoute(s.v.Name, "/%s/ads", campaignID).Limit(10).Fields(fmt.Sprintf("adcreatives{%v}", strings.Join(fields, ","))),
 	}
 }
 
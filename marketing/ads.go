@@ -1,4 +1,4 @@
-package v22
+package marketing
 
 import (
 	"context"
@@ -13,12 +13,13 @@ import (
 // AdService works with Ads.
 type AdService struct {
 	c *fb.Client
+	v Version
 }
 
 // Get returns a single ad.
 func (as *AdService) Get(ctx context.Context, id string) (*Ad, error) {
 	res := &Ad{}
-	err := as.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Fields("id", "creative", "name", "account_id", "adset_id",
+	err := as.c.GetJSON(ctx, fb.NewRoute(as.v.Name, "/%s", id).Fields("id", "creative", "name", "account_id", "adset_id",
 		"adset{id,daily_budget,name,start_time,end_time,status,bid_strategy,targeting{age_min,age_max,publisher_platforms,geo_locations,genders,custom_audiences,excluded_custom_audiences,flexible_spec,exclusions}}",
 		"adcreatives{id,title,object_story_spec}").Limit(1000).String(), res)
 	if err != nil {
@@ -41,7 +42,7 @@ func (as *AdService) Create(ctx context.Context, a Ad) (string, error) {
 	}
 
 	res := &fb.MinimalResponse{}
-	err := as.c.PostJSON(ctx, fb.NewRoute(Version, "/act_%s/ads", a.AccountID).String(), a, res)
+	err := as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/act_%s/ads", a.AccountID).String(), a, res)
 	if err != nil {
 		return "", err
 	} else if err = res.GetError(); err != nil {
@@ -60,7 +61,7 @@ func (as *AdService) Update(ctx context.Context, a Ad) error {
 	}
 
 	res := &fb.MinimalResponse{}
-	err := as.c.PostJSON(ctx, fb.NewRoute(Version, "/%s", a.ID).String(), a, res)
+	err := as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/%s", a.ID).String(), a, res)
 	if err != nil {
 		return err
 	} else if err = res.GetError(); err != nil {
@@ -72,10 +73,20 @@ func (as *AdService) Update(ctx context.Context, a Ad) error {
 	return nil
 }
 
+// Delete deletes an ad.
+func (as *AdService) Delete(ctx context.Context, id string) error {
+	return as.c.Delete(ctx, fb.NewRoute(as.v.Name, "/%s", id).String())
+}
+
+// Archive sets the status of an ad to ARCHIVED.
+func (as *AdService) Archive(ctx context.Context, id string) error {
+	return setStatus(ctx, as.c, as.v, id, StatusArchived)
+}
+
 // List returns all ads of an account.
 func (as *AdService) List(act string) *AdListCall {
 	return &AdListCall{
-		RouteBuilder: fb.NewRoute(Version, "/act_%s/ads", act).Fields("adset_id", "creative", "id", "name", "account_id", "adset{id}", "adcreatives{id}").Limit(1000),
+		RouteBuilder: fb.NewRoute(as.v.Name, "/act_%s/ads", act).Fields("adset_id", "creative", "id", "name", "account_id", "adset{id}", "adcreatives{id}").Limit(1000),
 		c:            as.c,
 	}
 }
@@ -83,7 +94,7 @@ func (as *AdService) List(act string) *AdListCall {
 // ListOfAdset returns all ads of an adset.
 func (as *AdService) ListOfAdset(adsetID string) *AdListCall {
 	return &AdListCall{
-		RouteBuilder: fb.NewRoute(Version, "/%s/ads", adsetID).Fields("id", "adset{id}", "adcreatives{id}").Limit(1000),
+		RouteBuilder: fb.NewRoute(as.v.Name, "/%s/ads", adsetID).Fields("id", "adset{id}", "adcreatives{id}").Limit(1000),
 		c:            as.c,
 	}
 }
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -16,15 +16,16 @@ const (
 // AdsetService is used for working with adsets.
 type AdsetService struct {
 	c *fb.Client
+	v Version
 }
 
 // Get returns a single Adset.
 func (as *AdsetService) Get(ctx context.Context, id string, fields ...string) (*Adset, error) {
 	if len(fields) == 0 {
-		fields = AdsetFields
+		fields = as.v.adsetFields()
 	}
 	res := &Adset{}
-	err := as.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Fields(fields...).String(), res)
+	err := as.c.GetJSON(ctx, fb.NewRoute(as.v.Name, "/%s", id).Fields(fields...).String(), res)
 	if err != nil {
 		if fb.IsNotFound(err) {
 			return nil, nil
@@ -38,7 +39,7 @@ func (as *AdsetService) Get(ctx context.Context, id string, fields ...string) (*
 
 // GetDeliveryEstimate returns the delivery_estimate mau for a given adset.
 func (as *AdsetService) GetDeliveryEstimate(ctx context.Context, id string, t *Targeting) (uint64, error) {
-	r := fb.NewRoute(Version, "/%s/delivery_estimate", id).Limit(10).Fields("estimate_mau_upper_bound")
+	r := fb.NewRoute(as.v.Name, "/%s/delivery_estimate", id).Limit(10).Fields("estimate_mau_upper_bound")
 
 	if t != nil {
 		r.TargetingSpec(t)
@@ -69,7 +70,7 @@ func (as *AdsetService) Create(ctx context.Context, a Adset) (string, fb.Time, e
 	}
 
 	res := &fb.MinimalResponse{}
-	err := as.c.PostJSON(ctx, fb.NewRoute(Version, "/act_%s/adsets", a.AccountID).Fields("updated_time", "id").String(), a, res)
+	err := as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/act_%s/adsets", a.AccountID).Fields("updated_time", "id").String(), a, res)
 	if err != nil {
 		return "", fb.Time{}, err
 	} else if err = res.GetError(); err != nil {
@@ -88,7 +89,7 @@ func (as *AdsetService) Update(ctx context.Context, a Adset) (fb.Time, error) {
 	}
 
 	res := &fb.MinimalResponse{}
-	err := as.c.PostJSON(ctx, fb.NewRoute(Version, "/%s", a.ID).Fields("updated_time", "id").String(), a, res)
+	err := as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/%s", a.ID).Fields("updated_time", "id").String(), a, res)
 	if err != nil {
 		return fb.Time{}, err
 	} else if err = res.GetError(); err != nil {
@@ -100,14 +101,24 @@ func (as *AdsetService) Update(ctx context.Context, a Adset) (fb.Time, error) {
 	return res.UpdatedTime, nil
 }
 
+// Delete deletes an adset together with its ads.
+func (as *AdsetService) Delete(ctx context.Context, id string) error {
+	return as.c.Delete(ctx, fb.NewRoute(as.v.Name, "/%s", id).String())
+}
+
+// Archive sets the status of an adset to ARCHIVED.
+func (as *AdsetService) Archive(ctx context.Context, id string) error {
+	return setStatus(ctx, as.c, as.v, id, StatusArchived)
+}
+
 // List returns a list of adsets for an account.
 func (as *AdsetService) List(account string, fields []string) *AdsetListCall {
 	if len(fields) == 0 {
-		fields = AdsetFields
+		fields = as.v.adsetFields()
 	}
 
 	return &AdsetListCall{
-		RouteBuilder: fb.NewRoute(Version, "/act_%s/adsets", account).Limit(adsetListLimit).Fields(fields...),
+		RouteBuilder: fb.NewRoute(as.v.Name, "/act_%s/adsets", account).Limit(adsetListLimit).Fields(fields...),
 		c:            as.c,
 	}
 }
@@ -115,11 +126,11 @@ func (as *AdsetService) List(account string, fields []string) *AdsetListCall {
 // ListOfCampaign returns an adsetlistcall for listing the adsets of a campaign id.
 func (as *AdsetService) ListOfCampaign(campaignID string, fields []string) *AdsetListCall {
 	if len(fields) == 0 {
-		fields = AdsetFields
+		fields = as.v.adsetFields()
 	}
 
 	return &AdsetListCall{
-		RouteBuilder: fb.NewRoute(Version, "/%s/adsets", campaignID).Limit(adsetListLimit).Fields(fields...),
+		RouteBuilder: fb.NewRoute(as.v.Name, "/%s/adsets", campaignID).Limit(adsetListLimit).Fields(fields...),
 		c:            as.c,
 	}
 }
@@ -127,7 +138,7 @@ func (as *AdsetService) ListOfCampaign(campaignID string, fields []string) *Adse
 // CountAdSets returns the total amount of active adsets.
 func (as *AdsetService) CountAdSets(ctx context.Context, accountID string) (uint64, error) {
 	sc := &fb.SummaryContainer{}
-	err := as.c.GetJSON(ctx, fb.NewRoute(Version, "/act_%s/adsets", accountID).Limit(0).Summary("1").String(), sc)
+	err := as.c.GetJSON(ctx, fb.NewRoute(as.v.Name, "/act_%s/adsets", accountID).Limit(0).Summary("1").String(), sc)
 
 	return sc.Summary.TotalCount, err
 }
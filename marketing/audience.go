@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -23,6 +23,8 @@ type AudienceSize struct {
 // AudienceService contains all methods for working on audiences.
 type AudienceService struct {
 	c *fb.Client
+	v Version
+	*fb.StatsContainer
 }
 
 // audienceFieldsCommon are the common fields used across most methods
@@ -44,9 +46,13 @@ func (as *AudienceService) Create(ctx context.Context, act string, a CustomAudie
 	} else if act == "" {
 		return "", errors.New("cannot create audience without account id")
 	}
+	err := a.applyRuleSpec()
+	if err != nil {
+		return "", err
+	}
 
 	res := &fb.MinimalResponse{}
-	err := as.c.PostJSON(ctx, fb.NewRoute(Version, "/act_%s/customaudiences", act).String(), a, res)
+	err = as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/act_%s/customaudiences", act).String(), a, res)
 	if err != nil {
 		return "", err
 	} else if err = res.GetError(); err != nil {
@@ -59,7 +65,7 @@ func (as *AudienceService) Create(ctx context.Context, act string, a CustomAudie
 }
 
 func (as AudienceService) GetAudienceSize(ctx context.Context, accountID string, t *Targeting) (AudienceSize, error) {
-	r := fb.NewRoute(Version, "/act_%s/reachestimate", accountID)
+	r := fb.NewRoute(as.v.Name, "/act_%s/reachestimate", accountID)
 
 	if t != nil {
 		r.TargetingSpec(t)
@@ -91,7 +97,7 @@ func (as *AudienceService) CreateLookalike(ctx context.Context, adaccountID, org
 	}
 
 	res := &fb.MinimalResponse{}
-	err := as.c.PostJSON(ctx, fb.NewRoute(Version, "/act_%s/customaudiences", adaccountID).String(), createLookalikeRequest{
+	err := as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/act_%s/customaudiences", adaccountID).String(), createLookalikeRequest{
 		OriginAudienceID: orginAudienceID,
 		Name:             customAudienceName,
 		Subtype:          "LOOKALIKE",
@@ -113,9 +119,13 @@ func (as *AudienceService) Update(ctx context.Context, a CustomAudience) error {
 	if a.ID == "" {
 		return errors.New("cannot update audience without id")
 	}
+	err := a.applyRuleSpec()
+	if err != nil {
+		return err
+	}
 
 	res := &fb.MinimalResponse{}
-	err := as.c.PostJSON(ctx, fb.NewRoute(Version, "/%s", a.ID).String(), a, res)
+	err = as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/%s", a.ID).String(), a, res)
 	if err != nil {
 		return err
 	} else if err = res.GetError(); err != nil {
@@ -180,7 +190,7 @@ func (as *AudienceService) ShareCustom(ctx context.Context, customAudienceID str
 		return nil
 	}
 
-	return as.c.PostJSON(ctx, fb.NewRoute(Version, "/%s/adaccounts", customAudienceID).String(), struct {
+	return as.c.PostJSON(ctx, fb.NewRoute(as.v.Name, "/%s/adaccounts", customAudienceID).String(), struct {
 		Adaccounts       []string `json:"adaccounts"`
 		RelationshipType []string `json:"relationship_type"`
 	}{adaccountIDs, relationshipTypes}, &struct{}{})
@@ -192,7 +202,7 @@ func (as *AudienceService) UnshareCustom(ctx context.Context, customAudienceID s
 		return nil
 	}
 
-	return as.c.DeleteJSON(ctx, fb.NewRoute(Version, "/%s/adaccounts", customAudienceID).String(), struct {
+	return as.c.DeleteJSON(ctx, fb.NewRoute(as.v.Name, "/%s/adaccounts", customAudienceID).String(), struct {
 		Adaccounts       []string `json:"adaccounts"`
 		RelationshipType []string `json:"relationship_type"`
 	}{adaccountIDs, relationshipTypes}, &struct{}{})
@@ -203,7 +213,7 @@ func (as *AudienceService) ListAdAccounts(ctx context.Context, audienceID string
 	res := struct {
 		Data []string `json:"data"`
 	}{}
-	err := as.c.GetJSON(ctx, fb.NewRoute(Version, "/%s/adaccounts", audienceID).String(), &res)
+	err := as.c.GetJSON(ctx, fb.NewRoute(as.v.Name, "/%s/adaccounts", audienceID).String(), &res)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +234,7 @@ func (as *AudienceService) GetSharedAccountInfo(ctx context.Context, customAudie
 	res := struct {
 		Data []SharedAccountInfo `json:"data"`
 	}{}
-	err := as.c.GetJSON(ctx, fb.NewRoute(Version, "/%s/shared_account_info", customAudienceID).String(), &res)
+	err := as.c.GetJSON(ctx, fb.NewRoute(as.v.Name, "/%s/shared_account_info", customAudienceID).String(), &res)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +244,7 @@ func (as *AudienceService) GetSharedAccountInfo(ctx context.Context, customAudie
 
 // Delete removes a single audience.
 func (as *AudienceService) Delete(ctx context.Context, id string) error {
-	return as.c.Delete(ctx, fb.NewRoute(Version, "/%s", id).String())
+	return as.c.Delete(ctx, fb.NewRoute(as.v.Name, "/%s", id).String())
 }
 
 // DeleteLookalikes removes all lookalikes of an audience.
@@ -259,9 +269,9 @@ func (as *AudienceService) DeleteLookalikes(ctx context.Context, id string) erro
 // Get returns a single audience.
 func (as *AudienceService) Get(ctx context.Context, id string) (*CustomAudience, error) {
 	res := &CustomAudience{}
-	additionalFields := []string{"rule", "customer_file_source", "lookalike_audience_ids"}
+	additionalFields := []string{"rule", "customer_file_source", "lookalike_audience_ids", "is_value_based"}
 	allFields := append(audienceFieldsCommon, additionalFields...)
-	err := as.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Fields(allFields...).String(), res)
+	err := as.c.GetJSON(ctx, fb.NewRoute(as.v.Name, "/%s", id).Fields(allFields...).String(), res)
 	if err != nil {
 		if fb.IsNotFound(err) {
 			return nil, nil
@@ -278,7 +288,7 @@ func (as *AudienceService) ListCustom(ctx context.Context, act string) ([]Custom
 	res := []CustomAudience{}
 	additionalFields := []string{"lookalike_spec"}
 	allFields := append(audienceFieldsCommon, additionalFields...)
-	route := fb.NewRoute(Version, "/act_%s/customaudiences", act).
+	route := fb.NewRoute(as.v.Name, "/act_%s/customaudiences", act).
 		Limit(250).
 		Fields(allFields...) // , "rule")
 	err := as.c.GetList(ctx, route.String(), &res)
@@ -293,7 +303,7 @@ func (as *AudienceService) ListCustom(ctx context.Context, act string) ([]Custom
 // ...&filtering=[{field:'subtype',operator:'EQUAL',value:'WEBSITE'}].
 func (as *AudienceService) ListCustomFiltered(ctx context.Context, act string, filtering []fb.Filter) ([]CustomAudience, error) {
 	res := []CustomAudience{}
-	route := fb.NewRoute(Version, "/act_%s/customaudiences", act).
+	route := fb.NewRoute(as.v.Name, "/act_%s/customaudiences", act).
 		Limit(250).
 		Fields(audienceFieldsCommon...). // , "rule")
 		Filtering(filtering...)
@@ -306,6 +316,7 @@ func (as *AudienceService) ListCustomFiltered(ctx context.Context, act string, f
 }
 
 // EditIDs starts adding or removing ids from a custom audience.
+// The ids are uploaded as MOBILE_ADVERTISER_ID without normalization, use Upload for customer lists.
 func (as *AudienceService) EditIDs(ctx context.Context, audienceID string, c <-chan string, doRemove bool) error {
 	bigN, err := rand.Int(rand.Reader, big.NewInt(math.MaxUint32))
 	if err != nil {
@@ -323,7 +334,7 @@ func (as *AudienceService) EditIDs(ctx context.Context, audienceID string, c <-c
 		}
 		total += uint64(len(ids))
 
-		route := fb.NewRoute(Version, "/%s/users", audienceID).String()
+		route := fb.NewRoute(as.v.Name, "/%s/users", audienceID).String()
 		req := editAudienceIDsRequest{
 			Session: uploadSession{
 				SessionID:     uint32(sessionID),
@@ -385,6 +396,15 @@ func readBatch(max int, c <-chan string, leftOver string) ([]string, string, boo
 	return s, leftOver, ok
 }
 
+// madIDRows returns CustomerRows of the non empty ids read from c.
+func madIDRows(c <-chan string) CustomerRows {
+	return func() (CustomerRow, bool, error) {
+		id, ok := read(c)
+
+		return CustomerRow{MadID: id}, ok && id != "", nil
+	}
+}
+
 func read(c <-chan string) (string, bool) {
 	var res string
 	ok := true
@@ -441,11 +461,51 @@ type CustomAudience struct {
 	ApproximateCountLowerBound int    `json:"approximate_count_lower_bound,omitempty"`
 
 	Rule               string         `json:"rule,omitempty"`
+	RuleSpec           *AudienceRule  `json:"-"` // replaces Rule in Create and Update
 	CustomerFileSource string         `json:"customer_file_source,omitempty"`
+	IsValueBased       bool           `json:"is_value_based,omitempty"`
 	Lookalikes         []string       `json:"lookalike_audience_ids,omitempty"`
 	Adaccounts         *Adaccounts    `json:"adaccounts,omitempty"`
 	LookalikeSpec      *LookalikeSpec `json:"lookalike_spec,omitempty"`
 	OriginAudienceID   string         `json:"origin_audience_id,omitempty"`
+	RetentionDays      int            `json:"retention_days,omitempty"`
+
+	// read only fields, see ListCustomHealth
+	OperationStatus      *AudienceStatus               `json:"operation_status,omitempty"`
+	DeliveryStatus       *AudienceStatus               `json:"delivery_status,omitempty"`
+	TimeUpdated          int64                         `json:"time_updated,omitempty"`
+	DataSource           *AudienceDataSource           `json:"data_source,omitempty"`
+	PermissionForActions *AudiencePermissionForActions `json:"permission_for_actions,omitempty"`
+	SharingStatus        *AudienceSharingStatus        `json:"sharing_status,omitempty"`
+}
+
+// AudiencePermissionForActions tells what the current user can do with an audience.
+type AudiencePermissionForActions struct {
+	CanEdit                    bool `json:"can_edit"`
+	CanSeeInsight              bool `json:"can_see_insight"`
+	CanShare                   bool `json:"can_share"`
+	SubtypeSupportsLookalike   bool `json:"subtype_supports_lookalike"`
+	SupportsRecipientLookalike bool `json:"supports_recipient_lookalike"`
+}
+
+// AudienceSharingStatus is the status of an audience shared from another account.
+type AudienceSharingStatus struct {
+	SharingRelationshipID json.Number `json:"sharing_relationship_id"`
+	Status                string      `json:"status"`
+}
+
+// applyRuleSpec validates RuleSpec and sets Rule to it.
+func (a *CustomAudience) applyRuleSpec() error {
+	if a.RuleSpec == nil {
+		return nil
+	}
+	err := a.RuleSpec.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid rule of audience '%s': %w", a.Name, err)
+	}
+	a.Rule = a.RuleSpec.String()
+
+	return nil
 }
 
 // LookalikeSpec contains the metadata of lookalike audiences.
@@ -455,6 +515,7 @@ type LookalikeSpec struct {
 	Ratio         float64            `json:"ratio,omitempty"`
 	Type          string             `json:"type,omitempty"`
 	StartingRatio float64            `json:"starting_ratio,omitempty"`
+	LocationSpec  *LocationSpec      `json:"location_spec,omitempty"`
 }
 
 // LocationSpec ...
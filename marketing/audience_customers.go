@@ -0,0 +1,201 @@
+package marketing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+// Keys of the customer list schema https://developers.facebook.com/docs/marketing-api/audiences/guides/custom-audiences#hash
+const (
+	CustomerEmail          = "EMAIL"
+	CustomerPhone          = "PHONE"
+	CustomerFirstName      = "FN"
+	CustomerLastName       = "LN"
+	CustomerZip            = "ZIP"
+	CustomerCity           = "CT"
+	CustomerState          = "ST"
+	CustomerCountry        = "COUNTRY"
+	CustomerBirthYear      = "DOBY"
+	CustomerGender         = "GEN"
+	CustomerMadID          = "MADID"
+	CustomerExternID       = "EXTERN_ID"
+	CustomerLookalikeValue = "LOOKALIKE_VALUE"
+)
+
+// Values of CustomAudience.CustomerFileSource.
+const (
+	CustomerFileSourceUserProvided    = "USER_PROVIDED_ONLY"
+	CustomerFileSourcePartnerProvided = "PARTNER_PROVIDED_ONLY"
+	CustomerFileSourceBoth            = "BOTH_USER_AND_PARTNER_PROVIDED"
+)
+
+// CustomerRow is a row of a customer list, only the values of the upload schema are sent.
+// Values are normalized and, except for MadID and ExternID, hashed with SHA-256.
+type CustomerRow struct {
+	Email     string
+	Phone     string
+	FirstName string
+	LastName  string
+	Zip       string
+	City      string
+	State     string
+	Country   string
+	BirthYear string
+	Gender    string
+	MadID     string
+	ExternID  string
+	// LookalikeValue is the customer value used by value-based audiences.
+	LookalikeValue float64
+}
+
+// CustomerRows iterates over customer rows, ok is false after the last row.
+type CustomerRows func() (row CustomerRow, ok bool, err error)
+
+// CustomerRowsFromChan returns CustomerRows reading c until it is closed.
+func CustomerRowsFromChan(c <-chan CustomerRow) CustomerRows {
+	return func() (CustomerRow, bool, error) {
+		row, ok := <-c
+
+		return row, ok, nil
+	}
+}
+
+// CustomerRowsFromSlice returns CustomerRows iterating over rows.
+func CustomerRowsFromSlice(rows []CustomerRow) CustomerRows {
+	i := 0
+
+	return func() (CustomerRow, bool, error) {
+		if i >= len(rows) {
+			return CustomerRow{}, false, nil
+		}
+		i++
+
+		return rows[i-1], true, nil
+	}
+}
+
+// AudienceDataSource describes where the uploaded data comes from.
+type AudienceDataSource struct {
+	Type    string `json:"type,omitempty"`
+	SubType string `json:"sub_type,omitempty"`
+}
+
+// CustomerUpload configures an upload of customer rows.
+type CustomerUpload struct {
+	// Schema are the customer list keys sent for every row, e.g. []string{CustomerEmail, CustomerPhone}.
+	Schema []string
+	// IsRaw marks the values as not hashed, they are only normalized and Meta hashes them.
+	IsRaw      bool
+	DataSource *AudienceDataSource
+}
+
+// Validate checks the schema keys.
+func (u CustomerUpload) Validate() error {
+	if len(u.Schema) == 0 {
+		return errors.New("missing schema")
+	}
+
+	seen := map[string]bool{}
+	for _, k := range u.Schema {
+		if _, ok := customerFields[k]; !ok {
+			return fmt.Errorf("unknown schema key '%s'", k)
+		} else if seen[k] {
+			return fmt.Errorf("duplicate schema key '%s'", k)
+		}
+		seen[k] = true
+	}
+	if len(u.Schema) == 1 && u.Schema[0] == CustomerLookalikeValue {
+		return errors.New("schema needs a key besides LOOKALIKE_VALUE")
+	}
+
+	return nil
+}
+
+type customerField struct {
+	value     func(CustomerRow) string
+	normalize func(string) string
+	hashed    bool
+}
+
+var customerFields = map[string]customerField{
+	CustomerEmail:     {func(r CustomerRow) string { return r.Email }, types.NormalizeEmail, true},
+	CustomerPhone:     {func(r CustomerRow) string { return r.Phone }, types.NormalizePhone, true},
+	CustomerFirstName: {func(r CustomerRow) string { return r.FirstName }, types.NormalizeName, true},
+	CustomerLastName:  {func(r CustomerRow) string { return r.LastName }, types.NormalizeName, true},
+	CustomerZip:       {func(r CustomerRow) string { return r.Zip }, types.NormalizeZip, true},
+	CustomerCity:      {func(r CustomerRow) string { return r.City }, types.NormalizeCity, true},
+	CustomerState:     {func(r CustomerRow) string { return r.State }, types.NormalizeState, true},
+	CustomerCountry:   {func(r CustomerRow) string { return r.Country }, types.NormalizeCountry, true},
+	CustomerBirthYear: {func(r CustomerRow) string { return r.BirthYear }, types.NormalizeBirthYear, true},
+	CustomerGender:    {func(r CustomerRow) string { return r.Gender }, types.NormalizeGender, true},
+	CustomerMadID:     {func(r CustomerRow) string { return r.MadID }, normalizeMadID, false},
+	CustomerExternID:  {func(r CustomerRow) string { return r.ExternID }, types.NormalizeExternalID, false},
+	// LOOKALIKE_VALUE is encoded as number by values
+	CustomerLookalikeValue: {},
+}
+
+func normalizeMadID(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// values returns the normalized and hashed values of the schema, ok is false if the row has no value to match.
+func (u CustomerUpload) values(r CustomerRow) ([]interface{}, bool) {
+	res := make([]interface{}, len(u.Schema))
+	ok := false
+	for i, k := range u.Schema {
+		if k == CustomerLookalikeValue {
+			res[i] = r.LookalikeValue
+
+			continue
+		}
+
+		f := customerFields[k]
+		raw := f.value(r)
+		v := f.normalize(raw)
+		if f.hashed && !u.IsRaw {
+			v = types.Hash(f.normalize, raw)
+		}
+		res[i] = v
+		ok = ok || v != ""
+	}
+
+	return res, ok
+}
+
+type customerPayload struct {
+	Schema     []string            `json:"schema"`
+	IsRaw      bool                `json:"is_raw,omitempty"`
+	DataSource *AudienceDataSource `json:"data_source,omitempty"`
+	Data       [][]interface{}     `json:"data"`
+}
+
+type editCustomersRequest struct {
+	Session uploadSession   `json:"session"`
+	Payload customerPayload `json:"payload"`
+}
+
+// readCustomers reads the next batch of rows, more is false if rows is exhausted.
+func readCustomers(u CustomerUpload, rows CustomerRows, skipped *uint64) ([][]interface{}, bool, error) {
+	batch := make([][]interface{}, 0, BatchMaxIDsSequence)
+	for len(batch) < BatchMaxIDsSequence {
+		row, ok, err := rows()
+		if err != nil {
+			return nil, false, err
+		} else if !ok {
+			return batch, false, nil
+		}
+
+		values, ok := u.values(row)
+		if !ok {
+			*skipped++
+
+			continue
+		}
+		batch = append(batch, values)
+	}
+
+	return batch, true, nil
+}
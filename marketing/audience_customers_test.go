@@ -0,0 +1,147 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+func TestAddCustomers(t *testing.T) {
+	var requests []editCustomersRequest
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceCustomersRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if request.Method != http.MethodPost || request.URL.Path != "/v24.0/aud-1/users" {
+			t.Fatalf("unexpected request %s %s", request.Method, request.URL.Path)
+		}
+		req := editCustomersRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		requests = append(requests, req)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"num_received":%d,"num_invalid_entries":0}`, len(req.Payload.Data)))),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+
+	c := make(chan CustomerRow)
+	go func() {
+		defer close(c)
+		c <- CustomerRow{Email: " Jane@Example.com", Country: "DE", MadID: "AB-CD", LookalikeValue: 12.5}
+		c <- CustomerRow{Country: "DE"}
+		for i := 0; i < BatchMaxIDsSequence; i++ {
+			c <- CustomerRow{Email: fmt.Sprintf("user%d@example.com", i)}
+		}
+	}()
+
+	u := CustomerUpload{
+		Schema:     []string{CustomerEmail, CustomerCountry, CustomerMadID, CustomerLookalikeValue},
+		DataSource: &AudienceDataSource{Type: "THIRD_PARTY_IMPORTED"},
+	}
+	err := service.AddCustomers(context.Background(), "aud-1", u, CustomerRowsFromChan(c))
+
+	// the row without email, phone or madid only has a country and is still sent,
+	// so all rows are received and no error is returned
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 || len(requests[0].Payload.Data) != BatchMaxIDsSequence || len(requests[1].Payload.Data) != 2 {
+		t.Fatalf("unexpected batches: %d", len(requests))
+	}
+	if requests[0].Session.LastBatchFlag || !requests[1].Session.LastBatchFlag || requests[1].Session.BatchSequence != 2 ||
+		requests[0].Session.SessionID != requests[1].Session.SessionID {
+		t.Fatalf("unexpected sessions: %+v %+v", requests[0].Session, requests[1].Session)
+	}
+
+	row := requests[0].Payload.Data[0]
+	want := []interface{}{types.Hash(types.NormalizeEmail, "jane@example.com"), types.Hash(types.NormalizeCountry, "de"), "ab-cd", 12.5}
+	if fmt.Sprint(row) != fmt.Sprint(want) {
+		t.Fatalf("row = %v, want %v", row, want)
+	}
+	if requests[0].Payload.DataSource.Type != "THIRD_PARTY_IMPORTED" {
+		t.Fatalf("unexpected payload data source: %+v", requests[0].Payload.DataSource)
+	}
+}
+
+func TestAddCustomersSkipsEmptyRows(t *testing.T) {
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceCustomersRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(`{"num_received":1,"num_invalid_entries":0}`)),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+
+	rows := CustomerRowsFromSlice([]CustomerRow{{Phone: "+49 151 1234567"}, {Email: " ", Gender: "unknown"}})
+	err := service.AddCustomers(context.Background(), "aud-1", CustomerUpload{Schema: []string{CustomerPhone, CustomerEmail, CustomerGender}}, rows)
+	uploadErr := &UploadError{}
+	if !errors.As(err, &uploadErr) || uploadErr.Total != 1 || uploadErr.Received != 1 || uploadErr.Failed != 1 {
+		t.Fatalf("err = %v, want upload error with one failed row", err)
+	}
+}
+
+func TestCustomerUploadValidate(t *testing.T) {
+	for _, schema := range [][]string{nil, {"EMAIL_SHA256"}, {CustomerEmail, CustomerEmail}, {CustomerLookalikeValue}} {
+		if err := (CustomerUpload{Schema: schema}).Validate(); err == nil {
+			t.Fatalf("schema %v: expected error", schema)
+		}
+	}
+}
+
+func TestEditIDs(t *testing.T) {
+	var requests []editAudienceIDsRequest
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceCustomersRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if request.Method != http.MethodDelete || request.URL.Path != "/v24.0/aud-1/users" {
+			t.Fatalf("unexpected request %s %s", request.Method, request.URL)
+		}
+		req := editAudienceIDsRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		requests = append(requests, req)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(`{"session_id":"1","num_received":2,"num_invalid_entries":1}`)),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+
+	c := make(chan string, 3)
+	c <- "AAAA-1"
+	c <- ""
+	c <- "bbbb-2"
+	close(c)
+	// invalid entries are not an error as long as all ids were received
+	if err := service.EditIDs(context.Background(), "aud-1", c, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0].Payload.Schema != "MOBILE_ADVERTISER_ID" || fmt.Sprint(requests[0].Payload.Data) != "[AAAA-1 bbbb-2]" {
+		t.Fatalf("unexpected requests %+v", requests)
+	}
+}
+
+type audienceCustomersRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f audienceCustomersRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
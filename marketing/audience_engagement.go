@@ -0,0 +1,232 @@
+package marketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Events of video engagement audiences.
+const (
+	VideoEventWatched   = "video_watched" // 3 seconds
+	VideoEvent10s       = "video_view_10s"
+	VideoEvent15s       = "video_view_15s"
+	VideoEvent25Percent = "video_view_25_percent"
+	VideoEvent50Percent = "video_view_50_percent"
+	VideoEvent75Percent = "video_view_75_percent"
+	VideoEventCompleted = "video_completed" // 95%
+)
+
+// Events of page engagement audiences.
+const (
+	PageEventEngaged     = "page_engaged"
+	PageEventVisited     = "page_visited"
+	PageEventLiked       = "page_liked"
+	PageEventMessaged    = "page_messaged"
+	PageEventCTAClicked  = "page_cta_clicked"
+	PageEventSaved       = "page_or_post_save"
+	PageEventPostEngaged = "page_post_interaction"
+)
+
+// Events of instagram engagement audiences.
+const (
+	InstagramEventAll      = "ig_business_profile_all"
+	InstagramEventEngaged  = "ig_business_profile_engaged"
+	InstagramEventVisited  = "ig_business_profile_visit"
+	InstagramEventMessaged = "ig_user_messaged_business"
+)
+
+// Events of lead form audiences.
+const (
+	LeadEventOpened    = "lead_generation_opened"
+	LeadEventSubmitted = "lead_generation_submitted"
+	LeadEventDropoff   = "lead_generation_dropoff"
+)
+
+// engagementEvents are the events each engagement event source type supports.
+var engagementEvents = map[string][]string{
+	RuleEventSourceVideo: {
+		VideoEventWatched, VideoEvent10s, VideoEvent15s, VideoEvent25Percent,
+		VideoEvent50Percent, VideoEvent75Percent, VideoEventCompleted,
+	},
+	RuleEventSourcePage: {
+		PageEventEngaged, PageEventVisited, PageEventLiked, PageEventMessaged,
+		PageEventCTAClicked, PageEventSaved, PageEventPostEngaged,
+	},
+	RuleEventSourceInstagram: {
+		InstagramEventAll, InstagramEventEngaged, InstagramEventVisited, InstagramEventMessaged,
+	},
+	RuleEventSourceLead: {
+		LeadEventOpened, LeadEventSubmitted, LeadEventDropoff,
+	},
+}
+
+// EngagementRule returns the rule of an engagement audience of the people who triggered the event
+// on any of the sources of the type within the retention.
+func EngagementRule(sourceType, event string, retention time.Duration, sourceIDs ...string) (*AudienceRule, error) {
+	events, ok := engagementEvents[sourceType]
+	if !ok {
+		return nil, fmt.Errorf("unknown engagement source type '%s'", sourceType)
+	} else if len(sourceIDs) == 0 {
+		return nil, fmt.Errorf("missing %s ids", sourceType)
+	}
+	known := false
+	for _, e := range events {
+		known = known || e == event
+	}
+	if !known {
+		return nil, fmt.Errorf("event '%s' is not supported by %s sources, use one of %v", event, sourceType, events)
+	}
+
+	r := AudienceSubRule{RetentionSeconds: int64(retention / time.Second)}
+	for _, id := range sourceIDs {
+		r.EventSources = append(r.EventSources, AudienceRuleEventSource{ID: id, Type: sourceType})
+	}
+	ar := NewAudienceRule(r.Where(EventIs(event)))
+	err := ar.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return ar, nil
+}
+
+// engagementAudience returns an audience to be passed to Create.
+func engagementAudience(name, sourceType, event string, retention time.Duration, sourceIDs ...string) (*CustomAudience, error) {
+	ar, err := EngagementRule(sourceType, event, retention, sourceIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CustomAudience{
+		Name:     name,
+		Subtype:  "ENGAGEMENT",
+		RuleSpec: ar,
+	}, nil
+}
+
+// VideoEngagementAudience returns an audience of the people who watched any of the videos, e.g. until VideoEventCompleted.
+// The videos must be posted by pages the ad account can promote. The audience is created by passing it to Create.
+func (as *AudienceService) VideoEngagementAudience(ctx context.Context, adaccountID, name, event string, retention time.Duration, videoIDs ...string) (*CustomAudience, error) {
+	a, err := engagementAudience(name, RuleEventSourceVideo, event, retention, videoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := as.promotePages(ctx, adaccountID)
+	if err != nil {
+		return nil, err
+	}
+	vs := &VideoService{as.c, as.v}
+	for _, id := range videoIDs {
+		v, err := vs.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		} else if v == nil {
+			return nil, fmt.Errorf("video %s not found or not accessible", id)
+		} else if _, ok := pages[v.From.ID]; !ok {
+			return nil, fmt.Errorf("video %s of %s does not belong to a page of ad account %s", id, v.From.ID, adaccountID)
+		}
+	}
+
+	return a, nil
+}
+
+// PageEngagementAudience returns an audience of the people who engaged with any of the pages.
+// The pages must be promote pages of the ad account. The audience is created by passing it to Create.
+func (as *AudienceService) PageEngagementAudience(ctx context.Context, adaccountID, name, event string, retention time.Duration, pageIDs ...string) (*CustomAudience, error) {
+	a, err := engagementAudience(name, RuleEventSourcePage, event, retention, pageIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = as.checkPages(ctx, adaccountID, pageIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// InstagramEngagementAudience returns an audience of the people who engaged with any of the instagram business accounts.
+// The accounts must be connected to promote pages of the ad account. The audience is created by passing it to Create.
+func (as *AudienceService) InstagramEngagementAudience(ctx context.Context, adaccountID, name, event string, retention time.Duration, igAccountIDs ...string) (*CustomAudience, error) {
+	a, err := engagementAudience(name, RuleEventSourceInstagram, event, retention, igAccountIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := as.promotePages(ctx, adaccountID)
+	if err != nil {
+		return nil, err
+	}
+	igAccounts := map[string]bool{}
+	for _, p := range pages {
+		if p.InstagramBusinessAccount != nil {
+			igAccounts[p.InstagramBusinessAccount.ID] = true
+		}
+	}
+	for _, id := range igAccountIDs {
+		if !igAccounts[id] {
+			return nil, fmt.Errorf("instagram account %s is not connected to a page of ad account %s", id, adaccountID)
+		}
+	}
+
+	return a, nil
+}
+
+// LeadFormAudience returns an audience of the people who opened or submitted a lead form of the page.
+// If formIDs are given, only these forms are matched. The page must be a promote page of the ad account.
+func (as *AudienceService) LeadFormAudience(ctx context.Context, adaccountID, name, event string, retention time.Duration, pageID string, formIDs ...string) (*CustomAudience, error) {
+	if pageID == "" {
+		return nil, errors.New("missing page id")
+	}
+	a, err := engagementAudience(name, RuleEventSourceLead, event, retention, pageID)
+	if err != nil {
+		return nil, err
+	}
+	if len(formIDs) > 0 {
+		r := &a.RuleSpec.Inclusions.Rules[0]
+		r.Filter.Filters = append(r.Filter.Filters, FieldFilter("lead_gen_form_id", "is_any", formIDs))
+	}
+
+	err = as.checkPages(ctx, adaccountID, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// checkPages returns an error if any of the pages is not a promote page of the ad account.
+func (as *AudienceService) checkPages(ctx context.Context, adaccountID string, pageIDs ...string) error {
+	pages, err := as.promotePages(ctx, adaccountID)
+	if err != nil {
+		return err
+	}
+	for _, id := range pageIDs {
+		if _, ok := pages[id]; !ok {
+			return fmt.Errorf("page %s is not a promote page of ad account %s", id, adaccountID)
+		}
+	}
+
+	return nil
+}
+
+// promotePages returns the promote pages of the ad account by id.
+func (as *AudienceService) promotePages(ctx context.Context, adaccountID string) (map[string]Page, error) {
+	if adaccountID == "" {
+		return nil, errors.New("missing ad account id")
+	}
+	pages, err := (&PageService{as.c, as.v}).GetPromotePages(ctx, adaccountID)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]Page, len(pages))
+	for _, p := range pages {
+		res[p.ID] = p
+	}
+
+	return res, nil
+}
@@ -0,0 +1,93 @@
+package marketing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestEngagementAudiences(t *testing.T) {
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceEngagementRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		id := strings.TrimPrefix(request.URL.Path, "/v24.0/")
+		body := `{"id":"` + id + `","from":{"id":"p1"}}`
+		switch id {
+		case "act_1/promote_pages":
+			body = `{"data":[{"id":"p1","instagram_business_account":{"id":"ig1"}},{"id":"p2"}]}`
+		case "public":
+			body = `{"id":"public","from":{"id":"p3"}}`
+		case "missing":
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     "404 Not Found",
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Unsupported get request.","type":"GraphMethodException","code":100,"error_subcode":33}}`)),
+				Request:    request,
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+	ctx := context.Background()
+	day := 24 * time.Hour
+
+	a, err := service.VideoEngagementAudience(ctx, "1", "viewers", VideoEventCompleted, 365*day, "v1", "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"inclusions":{"operator":"or","rules":[{"event_sources":[{"id":"v1","type":"video"},{"id":"v2","type":"video"}],"retention_seconds":31536000,` +
+		`"filter":{"operator":"and","filters":[{"field":"event","operator":"eq","value":"video_completed"}]}}]}}`
+	if a.Subtype != "ENGAGEMENT" || a.RuleSpec.String() != want {
+		t.Fatalf("unexpected audience %s %s", a.Subtype, a.RuleSpec)
+	}
+
+	a, err = service.LeadFormAudience(ctx, "1", "leads", LeadEventSubmitted, 90*day, "p1", "f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.applyRuleSpec(); err != nil || !strings.Contains(a.Rule, `{"field":"lead_gen_form_id","operator":"is_any","value":["f1"]}`) {
+		t.Fatalf("unexpected rule %s %v", a.Rule, err)
+	}
+
+	if _, err := service.PageEngagementAudience(ctx, "1", "fans", PageEventEngaged, 30*day, "p1", "p2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.PageEngagementAudience(ctx, "1", "fans", PageEventEngaged, 30*day, "p1", "p3"); err == nil {
+		t.Fatal("expected error for page of another account")
+	}
+	if _, err := service.VideoEngagementAudience(ctx, "1", "viewers", VideoEventCompleted, 30*day, "v1", "public"); err == nil {
+		t.Fatal("expected error for video of another page")
+	}
+	if _, err := service.VideoEngagementAudience(ctx, "1", "viewers", VideoEventCompleted, 30*day, "missing"); err == nil {
+		t.Fatal("expected error for inaccessible video")
+	}
+	if _, err := service.InstagramEngagementAudience(ctx, "1", "ig", InstagramEventEngaged, 30*day, "ig1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.InstagramEngagementAudience(ctx, "1", "ig", InstagramEventEngaged, 30*day, "ig2"); err == nil {
+		t.Fatal("expected error for instagram account of another page")
+	}
+	if _, err := service.InstagramEngagementAudience(ctx, "1", "ig", VideoEventCompleted, 30*day, "ig1"); err == nil {
+		t.Fatal("expected error for unsupported event")
+	}
+	if _, err := service.LeadFormAudience(ctx, "1", "leads", LeadEventOpened, 91*day, "p1"); err == nil {
+		t.Fatal("expected error for retention")
+	}
+}
+
+type audienceEngagementRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f audienceEngagementRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,189 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// audienceHealthFields are requested by ListCustomHealth besides audienceFieldsCommon.
+var audienceHealthFields = []string{
+	"operation_status",
+	"delivery_status",
+	"time_updated",
+	"retention_days",
+	"data_source",
+	"permission_for_actions",
+	"sharing_status",
+}
+
+// ListCustomHealth returns all custom audiences of an account including their status fields.
+// The status fields are read only, don't pass the audiences to Update.
+func (as *AudienceService) ListCustomHealth(ctx context.Context, act string) ([]CustomAudience, error) {
+	res := []CustomAudience{}
+	route := fb.NewRoute(as.v.Name, "/act_%s/customaudiences", act).
+		Limit(250).
+		Fields(append(append([]string{}, audienceFieldsCommon...), audienceHealthFields...)...)
+	err := as.c.GetList(ctx, route.String(), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Types of AudienceIssue.
+const (
+	AudienceIssueTooSmall  = "too_small"
+	AudienceIssueExpired   = "expired"
+	AudienceIssueStale     = "stale"
+	AudienceIssueNotShared = "not_shared"
+)
+
+// AudienceIssue is a problem found by an AudienceAnalyzer.
+type AudienceIssue struct {
+	Type    string
+	Message string
+	// AccountID is the account using the audience without having access, set for AudienceIssueNotShared.
+	AccountID string
+}
+
+// AudienceHealth lists the issues of an audience and the adsets targeting it.
+type AudienceHealth struct {
+	Audience CustomAudience
+	AdsetIDs []string
+	Issues   []AudienceIssue
+}
+
+// AudienceAnalyzer flags custom audiences that can't be delivered to properly.
+type AudienceAnalyzer struct {
+	// MinSize is the approximate size below which an audience is too small, defaults to 1000.
+	MinSize int
+	// StaleAfter is the age of the last update after which a customer list is stale, defaults to 30 days.
+	StaleAfter time.Duration
+
+	now func() time.Time
+}
+
+// AnalyzeHealth lists the custom audiences of act with ListCustomHealth and the adsets of act and the
+// accounts in adsetAccounts with AdsetService.List, and returns the audiences having issues.
+func (as *AudienceService) AnalyzeHealth(ctx context.Context, adsets *AdsetService, act string, adsetAccounts []string, aa AudienceAnalyzer) ([]AudienceHealth, error) {
+	audiences, err := as.ListCustomHealth(ctx, act)
+	if err != nil {
+		return nil, err
+	}
+
+	all := []Adset{}
+	for _, account := range append([]string{act}, adsetAccounts...) {
+		res, err := adsets.List(account, []string{"id", "name", "account_id", "targeting"}).Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list adsets of account %s: %w", account, err)
+		}
+		for i := range res {
+			if res[i].AccountID == "" {
+				res[i].AccountID = account
+			}
+		}
+		all = append(all, res...)
+	}
+
+	return aa.Analyze(audiences, all), nil
+}
+
+// Analyze returns the audiences having issues in the order of audiences.
+// The adsets need the account_id and targeting fields.
+func (aa AudienceAnalyzer) Analyze(audiences []CustomAudience, adsets []Adset) []AudienceHealth {
+	minSize := aa.MinSize
+	if minSize == 0 {
+		minSize = 1000
+	}
+	staleAfter := aa.StaleAfter
+	if staleAfter == 0 {
+		staleAfter = 30 * 24 * time.Hour
+	}
+	now := time.Now
+	if aa.now != nil {
+		now = aa.now
+	}
+
+	usedBy := map[string][]Adset{}
+	for _, a := range adsets {
+		if a.Targeting == nil {
+			continue
+		}
+		for _, ca := range a.Targeting.CustomAudiences {
+			usedBy[ca.ID] = append(usedBy[ca.ID], a)
+		}
+		for _, ca := range a.Targeting.ExcludedCustomAudiences {
+			usedBy[ca.ID] = append(usedBy[ca.ID], a)
+		}
+	}
+
+	res := []AudienceHealth{}
+	for _, ca := range audiences {
+		h := AudienceHealth{Audience: ca}
+		for _, a := range usedBy[ca.ID] {
+			h.AdsetIDs = append(h.AdsetIDs, a.ID)
+		}
+
+		if ca.DeliveryStatus != nil && ca.DeliveryStatus.Code == 300 ||
+			ca.ApproximateCountUpperBound > 0 && ca.ApproximateCountUpperBound < minSize {
+			h.Issues = append(h.Issues, AudienceIssue{
+				Type:    AudienceIssueTooSmall,
+				Message: fmt.Sprintf("audience has about %d to %d people", ca.ApproximateCountLowerBound, ca.ApproximateCountUpperBound),
+			})
+		}
+		if ca.DeliveryStatus != nil && ca.DeliveryStatus.Code >= 400 {
+			h.Issues = append(h.Issues, AudienceIssue{
+				Type:    AudienceIssueExpired,
+				Message: ca.DeliveryStatus.Description,
+			})
+		}
+		if ca.Subtype == "CUSTOM" && ca.TimeUpdated > 0 {
+			updated := time.Unix(ca.TimeUpdated, 0)
+			if age := now().Sub(updated); age > staleAfter {
+				h.Issues = append(h.Issues, AudienceIssue{
+					Type:    AudienceIssueStale,
+					Message: fmt.Sprintf("customer list was last updated %s", updated.UTC().Format(time.RFC3339)),
+				})
+			}
+		}
+
+		notShared := map[string]bool{}
+		for _, a := range usedBy[ca.ID] {
+			if a.AccountID == "" || notShared[a.AccountID] || hasAccess(ca, a.AccountID) {
+				continue
+			}
+			notShared[a.AccountID] = true
+			h.Issues = append(h.Issues, AudienceIssue{
+				Type:      AudienceIssueNotShared,
+				Message:   fmt.Sprintf("adset %s targets the audience, but it is not shared to account %s", a.ID, a.AccountID),
+				AccountID: a.AccountID,
+			})
+		}
+
+		if len(h.Issues) > 0 {
+			res = append(res, h)
+		}
+	}
+
+	return res
+}
+
+// hasAccess returns whether the audience is owned by or shared to the account.
+func hasAccess(ca CustomAudience, accountID string) bool {
+	if ca.AccountID == accountID {
+		return true
+	}
+	if ca.Adaccounts != nil {
+		for _, id := range ca.Adaccounts.Data {
+			if id.String() == accountID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
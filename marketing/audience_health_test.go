@@ -0,0 +1,40 @@
+package marketing
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAudienceAnalyzer(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	audiences := []CustomAudience{
+		{ID: "healthy", AccountID: "1", Subtype: "CUSTOM", ApproximateCountUpperBound: 50000, TimeUpdated: now.Add(-24 * time.Hour).Unix(), DeliveryStatus: &AudienceStatus{Code: 200}},
+		{ID: "small", AccountID: "1", Subtype: "WEBSITE", ApproximateCountLowerBound: 100, ApproximateCountUpperBound: 200},
+		{ID: "expired", AccountID: "1", Subtype: "WEBSITE", DeliveryStatus: &AudienceStatus{Code: 400, Description: "This audience is expired"}},
+		{ID: "stale", AccountID: "1", Subtype: "CUSTOM", TimeUpdated: now.Add(-60 * 24 * time.Hour).Unix()},
+		{ID: "unshared", AccountID: "1", Adaccounts: &Adaccounts{Data: []json.Number{"2"}}},
+	}
+	adsets := []Adset{
+		{ID: "a1", AccountID: "1", Targeting: &Targeting{CustomAudiences: []IDContainer{{ID: "healthy"}, {ID: "unshared"}}}},
+		{ID: "a2", AccountID: "2", Targeting: &Targeting{CustomAudiences: []IDContainer{{ID: "unshared"}}}},
+		{ID: "a3", AccountID: "3", Targeting: &Targeting{ExcludedCustomAudiences: []IDContainer{{ID: "unshared"}}}},
+		{ID: "a4", AccountID: "3", Targeting: &Targeting{CustomAudiences: []IDContainer{{ID: "unshared"}}}},
+	}
+
+	res := AudienceAnalyzer{now: func() time.Time { return now }}.Analyze(audiences, adsets)
+	got := []string{}
+	for _, h := range res {
+		for _, i := range h.Issues {
+			got = append(got, h.Audience.ID+":"+i.Type+i.AccountID)
+		}
+	}
+	want := "[small:too_small expired:expired stale:stale unshared:not_shared3]"
+	if fmt.Sprint(got) != want {
+		t.Fatalf("issues = %v, want %v", got, want)
+	}
+	if fmt.Sprint(res[3].AdsetIDs) != "[a1 a2 a3 a4]" {
+		t.Fatalf("unexpected adsets %v", res[3].AdsetIDs)
+	}
+}
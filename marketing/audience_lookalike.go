@@ -0,0 +1,273 @@
+package marketing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// DefaultLookalikeNameTemplate is used when LookalikeMatrix.NameTemplate is empty.
+const DefaultLookalikeNameTemplate = "{{.OriginName}} - Lookalike ({{.Location}}, {{.StartPercent}}% to {{.EndPercent}}%)"
+
+// LookalikeRatio is a range of the population of a location, e.g. {0.01, 0.02} for the second percent.
+type LookalikeRatio struct {
+	Start float64
+	End   float64
+}
+
+// LookalikeMatrix describes the lookalikes of an origin audience for all combinations of locations and ratios.
+type LookalikeMatrix struct {
+	OriginAudienceID string
+	Countries        []string
+	CountryGroups    []string
+	Ratios           []LookalikeRatio
+	// Type is the optional lookalike_spec type, e.g. "similarity" or "reach".
+	Type string
+	// NameTemplate is a text/template executed with a LookalikeVariant.
+	NameTemplate string
+}
+
+// LookalikeVariant is a single lookalike of a LookalikeMatrix.
+type LookalikeVariant struct {
+	OriginAudienceID string
+	OriginName       string
+	// Location is the country or country group code.
+	Location      string
+	IsGroup       bool
+	StartingRatio float64
+	Ratio         float64
+	StartPercent  int
+	EndPercent    int
+}
+
+// Spec returns the lookalike_spec of the variant.
+func (lv LookalikeVariant) Spec(typ string) *LookalikeSpec {
+	spec := &LookalikeSpec{
+		Type:          typ,
+		StartingRatio: lv.StartingRatio,
+		Ratio:         lv.Ratio,
+	}
+	if lv.IsGroup {
+		spec.LocationSpec = &LocationSpec{GeoLocations: &GeoLocation{CountryGroups: []string{lv.Location}}}
+	} else {
+		spec.Country = lv.Location
+	}
+
+	return spec
+}
+
+// Variants returns all lookalikes of the matrix, ordered by location and ratio.
+func (m LookalikeMatrix) Variants(originName string) ([]LookalikeVariant, error) {
+	if m.OriginAudienceID == "" {
+		return nil, errors.New("missing origin audience id")
+	} else if len(m.Countries)+len(m.CountryGroups) == 0 {
+		return nil, errors.New("missing countries or country groups")
+	} else if len(m.Ratios) == 0 {
+		return nil, errors.New("missing ratios")
+	}
+	for _, r := range m.Ratios {
+		if r.Start < 0 || r.End <= r.Start || r.End > 0.2 {
+			return nil, fmt.Errorf("invalid ratio range %v to %v, it must be within 0 and 0.2", r.Start, r.End)
+		}
+	}
+
+	res := []LookalikeVariant{}
+	add := func(location string, isGroup bool) {
+		for _, r := range m.Ratios {
+			res = append(res, LookalikeVariant{
+				OriginAudienceID: m.OriginAudienceID,
+				OriginName:       originName,
+				Location:         location,
+				IsGroup:          isGroup,
+				StartingRatio:    r.Start,
+				Ratio:            r.End,
+				StartPercent:     int(math.Round(r.Start * 100)),
+				EndPercent:       int(math.Round(r.End * 100)),
+			})
+		}
+	}
+	for _, c := range m.Countries {
+		add(strings.ToUpper(c), false)
+	}
+	for _, g := range m.CountryGroups {
+		add(strings.ToLower(g), true)
+	}
+
+	return res, nil
+}
+
+// LookalikeStatus is the state of a lookalike created from a LookalikeMatrix.
+type LookalikeStatus struct {
+	Variant LookalikeVariant
+	Name    string
+	// ID is empty if the creation failed with Err.
+	ID              string
+	Err             error
+	OperationStatus *AudienceStatus
+	DeliveryStatus  *AudienceStatus
+}
+
+// Ready returns whether the lookalike is populated and can be delivered to.
+func (ls LookalikeStatus) Ready() bool {
+	return ls.Err == nil && ls.OperationStatus.Normal() && ls.DeliveryStatus.Normal()
+}
+
+// Done returns whether polling the lookalike can stop.
+func (ls LookalikeStatus) Done() bool {
+	return ls.Err != nil || ls.Ready() || ls.OperationStatus.Failed()
+}
+
+// AudienceStatus is the operation_status or delivery_status of an audience.
+type AudienceStatus struct {
+	Code        int    `json:"code"`
+	Description string `json:"description"`
+}
+
+// Normal returns whether the status code is 200.
+func (s *AudienceStatus) Normal() bool {
+	return s != nil && s.Code == 200
+}
+
+// failedAudienceStatusCodes are the codes of errors which need an action. Other codes, e.g. 441 while a
+// lookalike is populated or 434 while its build is retried, change without action.
+var failedAudienceStatusCodes = map[int]bool{
+	400: true, // error
+	410: true, // no upload
+	411: true, // low match rate
+	412: true, // high rate of invalid entries
+	421: true, // no pixel
+	422: true, // pixel not firing
+	423: true, // invalid pixel
+	431: true, // lookalike refresh failed
+	432: true, // lookalike build failed
+	433: true, // lookalike build failed
+}
+
+// Failed returns whether the status is an error which needs an action.
+func (s *AudienceStatus) Failed() bool {
+	return s != nil && failedAudienceStatusCodes[s.Code]
+}
+
+// LookalikeOptions configure AudienceService.CreateLookalikes.
+type LookalikeOptions struct {
+	// Concurrency is the number of lookalikes created in parallel, defaults to 1.
+	Concurrency int
+	// PollInterval is the interval of checking the status of the lookalikes, the statuses are not polled if it is zero.
+	PollInterval time.Duration
+}
+
+// CreateLookalikes creates all lookalikes of the matrix in the account and returns their status.
+// Failed creations are reported in the status and don't stop the others.
+// If opts.PollInterval is set, it waits until each lookalike is ready or failed, see WaitLookalikes.
+func (as *AudienceService) CreateLookalikes(ctx context.Context, adaccountID string, m LookalikeMatrix, opts LookalikeOptions) ([]LookalikeStatus, error) {
+	origin, err := as.Get(ctx, m.OriginAudienceID)
+	if err != nil {
+		return nil, err
+	} else if origin == nil {
+		return nil, fmt.Errorf("did not find custom audience %s", m.OriginAudienceID)
+	}
+	variants, err := m.Variants(origin.Name)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := m.NameTemplate
+	if tmpl == "" {
+		tmpl = DefaultLookalikeNameTemplate
+	}
+	t, err := template.New("name").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+
+	res := make([]LookalikeStatus, len(variants))
+	for i, v := range variants {
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name template: %w", err)
+		}
+		res[i] = LookalikeStatus{Variant: v, Name: buf.String()}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i := range res {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(ls *LookalikeStatus) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			ls.ID, ls.Err = as.CreateLookalike(ctx, adaccountID, m.OriginAudienceID, ls.Name, ls.Variant.Spec(m.Type))
+		}(&res[i])
+	}
+	wg.Wait()
+
+	if opts.PollInterval > 0 {
+		return res, as.WaitLookalikes(ctx, res, opts.PollInterval)
+	}
+
+	return res, nil
+}
+
+// WaitLookalikes updates the statuses every interval until all lookalikes are done or ctx is cancelled.
+// Errors getting a status are returned as they are not specific to a single lookalike.
+func (as *AudienceService) WaitLookalikes(ctx context.Context, statuses []LookalikeStatus, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		done := true
+		for i := range statuses {
+			ls := &statuses[i]
+			if ls.Done() {
+				continue
+			}
+			a, err := as.GetStatus(ctx, ls.ID)
+			if err != nil {
+				return err
+			} else if a == nil {
+				return fmt.Errorf("did not find lookalike audience %s", ls.ID)
+			}
+			ls.OperationStatus, ls.DeliveryStatus = a.OperationStatus, a.DeliveryStatus
+			done = done && ls.Done()
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// GetStatus returns an audience with only the id, name, operation_status and delivery_status fields.
+func (as *AudienceService) GetStatus(ctx context.Context, id string) (*CustomAudience, error) {
+	res := &CustomAudience{}
+	err := as.c.GetJSON(ctx, fb.NewRoute(as.v.Name, "/%s", id).Fields("id", "name", "operation_status", "delivery_status").String(), res)
+	if err != nil {
+		if fb.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
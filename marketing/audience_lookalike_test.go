@@ -0,0 +1,123 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestCreateLookalikes(t *testing.T) {
+	var mu sync.Mutex
+	created := map[string]LookalikeSpec{}
+	polls := map[string]int{}
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceLookalikeRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		status, body := http.StatusOK, ""
+		switch {
+		case request.Method == http.MethodGet && request.URL.Path == "/v24.0/origin":
+			body = `{"id":"origin","name":"Buyers"}`
+		case request.Method == http.MethodPost && request.URL.Path == "/v24.0/act_1/customaudiences":
+			req := struct {
+				Name          string        `json:"name"`
+				Subtype       string        `json:"subtype"`
+				LookalikeSpec LookalikeSpec `json:"lookalike_spec"`
+			}{}
+			if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			if req.LookalikeSpec.LocationSpec != nil {
+				status, body = http.StatusBadRequest, `{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`
+
+				break
+			}
+			created[req.Name] = req.LookalikeSpec
+			body = `{"id":"lal-` + req.LookalikeSpec.Country + `"}`
+		case request.Method == http.MethodGet:
+			id := strings.TrimPrefix(request.URL.Path, "/v24.0/")
+			polls[id]++
+			code := 300
+			if polls[id] == 2 {
+				code = 441
+			} else if polls[id] > 2 {
+				code = 200
+			}
+			b, _ := json.Marshal(CustomAudience{
+				ID:              id,
+				OperationStatus: &AudienceStatus{Code: code},
+				DeliveryStatus:  &AudienceStatus{Code: 200},
+			})
+			body = string(b)
+		default:
+			t.Fatalf("unexpected request %s %s", request.Method, request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+
+	m := LookalikeMatrix{
+		OriginAudienceID: "origin",
+		Countries:        []string{"de", "us"},
+		CountryGroups:    []string{"europe"},
+		Ratios:           []LookalikeRatio{{0, 0.01}},
+	}
+	res, err := service.CreateLookalikes(context.Background(), "1", m, LookalikeOptions{Concurrency: 2, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 3 || len(created) != 2 || created["Buyers - Lookalike (DE, 0% to 1%)"].Ratio != 0.01 {
+		t.Fatalf("unexpected lookalikes %+v", created)
+	}
+	if !res[0].Ready() || res[0].ID != "lal-DE" || !res[1].Ready() {
+		t.Fatalf("unexpected status %+v", res)
+	}
+	if res[2].Err == nil || res[2].Ready() || polls["lal-DE"] != 3 {
+		t.Fatalf("unexpected status %+v, polls %v", res[2], polls)
+	}
+}
+
+func TestAudienceStatusFailed(t *testing.T) {
+	for code, failed := range map[int]bool{200: false, 300: false, 411: true, 432: true, 434: false, 441: false} {
+		if got := (&AudienceStatus{Code: code}).Failed(); got != failed {
+			t.Fatalf("Failed() of %d = %t, want %t", code, got, failed)
+		}
+	}
+	if (LookalikeStatus{OperationStatus: &AudienceStatus{Code: 441}, DeliveryStatus: &AudienceStatus{Code: 200}}).Done() {
+		t.Fatal("populating lookalike is done")
+	}
+}
+
+func TestLookalikeMatrixVariants(t *testing.T) {
+	for _, m := range []LookalikeMatrix{
+		{Countries: []string{"DE"}, Ratios: []LookalikeRatio{{0, 0.01}}},
+		{OriginAudienceID: "1", Ratios: []LookalikeRatio{{0, 0.01}}},
+		{OriginAudienceID: "1", Countries: []string{"DE"}},
+		{OriginAudienceID: "1", Countries: []string{"DE"}, Ratios: []LookalikeRatio{{0.02, 0.01}}},
+		{OriginAudienceID: "1", Countries: []string{"DE"}, Ratios: []LookalikeRatio{{0, 0.3}}},
+	} {
+		if _, err := m.Variants(""); err == nil {
+			t.Fatalf("matrix %+v: expected error", m)
+		}
+	}
+}
+
+type audienceLookalikeRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f audienceLookalikeRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
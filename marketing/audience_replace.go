@@ -0,0 +1,113 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// AudienceSession is the processing state of an upload session https://developers.facebook.com/docs/marketing-api/reference/custom-audience/sessions/
+type AudienceSession struct {
+	SessionID         json.Number `json:"session_id"`
+	StartTime         int64       `json:"start_time"`
+	EndTime           int64       `json:"end_time"`
+	NumReceived       uint64      `json:"num_received"`
+	NumMatched        uint64      `json:"num_matched"`
+	NumInvalidEntries uint64      `json:"num_invalid_entries"`
+	Stage             string      `json:"stage"`
+}
+
+// Done returns whether Meta finished processing the session.
+func (s AudienceSession) Done() bool {
+	return s.EndTime > 0
+}
+
+// defaultWaitInterval is the default UploadOptions.WaitInterval.
+const defaultWaitInterval = 10 * time.Second
+
+// ReplaceReport is the result of a replace session. Received and Invalid are counted while uploading,
+// Session contains the matched count once Meta processed the upload.
+type ReplaceReport struct {
+	UploadReport
+	Session *AudienceSession
+}
+
+// ReplaceIDs replaces all users of a custom audience with the mobile advertiser ids read from c.
+// See ReplaceUsers.
+func (as *AudienceService) ReplaceIDs(ctx context.Context, audienceID string, c <-chan string) (*ReplaceReport, error) {
+	return as.ReplaceUsers(ctx, audienceID, CustomerUpload{Schema: []string{CustomerMadID}}, madIDRows(c), UploadOptions{})
+}
+
+// ReplaceUsers replaces all users of a custom audience with the rows using the usersreplace endpoint.
+// The users are swapped by Meta once the last batch was uploaded, so the audience is never half updated.
+// After the upload, the session is polled every opts.WaitInterval until Meta processed it, so the report
+// contains the matched count. If ctx is done before, the report contains the last state of the session.
+// If the upload fails, the returned report contains the progress so far and the old users are kept.
+// An audience can't be replaced with no users, an error is returned if there are no rows with values.
+// Like AddCustomers, an *UploadError is returned if rows were not received or invalid.
+// The mode of opts is ignored.
+func (as *AudienceService) ReplaceUsers(ctx context.Context, audienceID string, u CustomerUpload, rows CustomerRows, opts UploadOptions) (*ReplaceReport, error) {
+	opts.Mode = UploadReplace
+	r, err := as.Upload(ctx, audienceID, u, rows, opts)
+	if r == nil {
+		return nil, err
+	}
+	report := &ReplaceReport{UploadReport: *r}
+	if err != nil {
+		return report, err
+	} else if r.Batches == 0 {
+		return report, errors.New("no users to replace the audience with, it was left unchanged")
+	}
+
+	if opts.WaitInterval <= 0 {
+		opts.WaitInterval = defaultWaitInterval
+	}
+	report.Session, err = as.WaitSession(ctx, audienceID, r.SessionID, opts.WaitInterval)
+	if err != nil {
+		return report, err
+	}
+
+	return report, r.Err()
+}
+
+// GetSession returns the state of an upload session, or nil if the session is not known yet.
+func (as *AudienceService) GetSession(ctx context.Context, audienceID string, sessionID uint32) (*AudienceSession, error) {
+	res := []AudienceSession{}
+	route := fb.NewRoute(as.v.Name, "/%s/sessions", audienceID).SessionID(strconv.FormatUint(uint64(sessionID), 10))
+	err := as.c.GetList(ctx, route.String(), &res)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range res {
+		if s.SessionID.String() == strconv.FormatUint(uint64(sessionID), 10) {
+			return &s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// WaitSession polls the session every interval until Meta finished processing it.
+func (as *AudienceService) WaitSession(ctx context.Context, audienceID string, sessionID uint32, interval time.Duration) (*AudienceSession, error) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		s, err := as.GetSession(ctx, audienceID, sessionID)
+		if err != nil {
+			return nil, err
+		} else if s != nil && s.Done() {
+			return s, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return s, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestReplaceIDs(t *testing.T) {
+	var sessionID uint32
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceCustomersRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case request.Method == http.MethodPost && request.URL.Path == "/v24.0/aud-1/usersreplace":
+			req := editCustomersRequest{}
+			if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			if fmt.Sprint(req.Payload.Schema) != "[MADID]" || !req.Session.LastBatchFlag || len(req.Payload.Data) != 2 {
+				t.Fatalf("unexpected request: %+v", req)
+			}
+			sessionID = req.Session.SessionID
+			body = `{"session_id":"1","num_received":2,"num_invalid_entries":1}`
+		case request.Method == http.MethodGet && request.URL.Path == "/v24.0/aud-1/sessions":
+			if request.URL.Query().Get("session_id") != fmt.Sprint(sessionID) {
+				t.Fatalf("unexpected session_id %s", request.URL.Query().Get("session_id"))
+			}
+			body = fmt.Sprintf(`{"data":[{"session_id":"%d","start_time":1700000000,"end_time":1700000100,"num_received":2,"num_matched":1,"num_invalid_entries":1,"stage":"completed"}]}`, sessionID)
+		default:
+			t.Fatalf("unexpected request %s %s", request.Method, request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+
+	c := make(chan string, 3)
+	c <- "AAAA-1"
+	c <- ""
+	c <- "bbbb-2"
+	close(c)
+	report, err := service.ReplaceIDs(context.Background(), "aud-1", c)
+	uploadErr := &UploadError{}
+	if !errors.As(err, &uploadErr) || uploadErr.Failed != 1 {
+		t.Fatalf("got error %v, want UploadError with 1 failed row", err)
+	}
+	if report.SessionID != sessionID || report.Batches != 1 || report.Total != 2 || report.Received != 2 || report.Invalid != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if report.Session == nil || !report.Session.Done() || report.Session.NumMatched != 1 {
+		t.Fatalf("unexpected session: %+v", report.Session)
+	}
+}
+
+func TestReplaceUsersWaitsForSession(t *testing.T) {
+	var sessionID uint32
+	var polls int
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceCustomersRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case request.Method == http.MethodPost && request.URL.Path == "/v24.0/aud-1/usersreplace":
+			req := editCustomersRequest{}
+			if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			sessionID = req.Session.SessionID
+			body = `{"session_id":"1","num_received":1,"num_invalid_entries":0}`
+		case request.Method == http.MethodGet && request.URL.Path == "/v24.0/aud-1/sessions":
+			polls++
+			endTime := 0
+			if polls == 2 {
+				endTime = 1700000100
+			}
+			body = fmt.Sprintf(`{"data":[{"session_id":"%d","start_time":1700000000,"end_time":%d,"num_received":1,"num_matched":1}]}`, sessionID, endTime)
+		default:
+			t.Fatalf("unexpected request %s %s", request.Method, request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+	u := CustomerUpload{Schema: []string{CustomerMadID}}
+
+	c := make(chan string, 1)
+	c <- "AAAA-1"
+	close(c)
+	report, err := service.ReplaceUsers(context.Background(), "aud-1", u, madIDRows(c), UploadOptions{WaitInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls != 2 || report.Session == nil || !report.Session.Done() || report.Session.NumMatched != 1 {
+		t.Fatalf("unexpected session after %d polls: %+v", polls, report.Session)
+	}
+
+	empty := make(chan string)
+	close(empty)
+	if _, err := service.ReplaceUsers(context.Background(), "aud-1", u, madIDRows(empty), UploadOptions{}); err == nil {
+		t.Fatal("expected error for replacing with no users")
+	}
+}
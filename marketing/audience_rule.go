@@ -0,0 +1,391 @@
+package marketing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRuleRetention is the longest retention of a website custom audience rule.
+const MaxRuleRetention = 180 * 24 * time.Hour
+
+// Event source types of AudienceRuleEventSource.
+const (
+	RuleEventSourcePixel     = "pixel"
+	RuleEventSourceApp       = "app"
+	RuleEventSourceVideo     = "video"
+	RuleEventSourcePage      = "page"
+	RuleEventSourceInstagram = "ig_business"
+	RuleEventSourceLead      = "lead"
+)
+
+// ruleRetentionLimits are the longest retentions of the event source types, other types use MaxRuleRetention.
+var ruleRetentionLimits = map[string]time.Duration{
+	RuleEventSourceVideo:     365 * 24 * time.Hour,
+	RuleEventSourcePage:      365 * 24 * time.Hour,
+	RuleEventSourceInstagram: 365 * 24 * time.Hour,
+	RuleEventSourceLead:      90 * 24 * time.Hour,
+}
+
+// AudienceRule is the rule of a website or app custom audience
+// https://developers.facebook.com/docs/marketing-api/audiences/guides/website-custom-audiences
+type AudienceRule struct {
+	Inclusions *AudienceRuleSet `json:"inclusions,omitempty"`
+	Exclusions *AudienceRuleSet `json:"exclusions,omitempty"`
+}
+
+// AudienceRuleSet combines rules with the operator "or" or "and".
+type AudienceRuleSet struct {
+	Operator string            `json:"operator"`
+	Rules    []AudienceSubRule `json:"rules"`
+}
+
+// AudienceSubRule matches the people who triggered events of the event sources within the retention.
+type AudienceSubRule struct {
+	EventSources     []AudienceRuleEventSource `json:"event_sources"`
+	RetentionSeconds int64                     `json:"retention_seconds"`
+	Filter           *RuleFilter               `json:"filter,omitempty"`
+	Aggregation      *RuleAggregation          `json:"aggregation,omitempty"`
+	Template         string                    `json:"template,omitempty"`
+}
+
+// AudienceRuleEventSource is the pixel or app the events come from.
+type AudienceRuleEventSource struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// RuleFilter either compares Field with Value, or combines Filters with the operator "and" or "or".
+type RuleFilter struct {
+	Field    string       `json:"field,omitempty"`
+	Operator string       `json:"operator"`
+	Value    interface{}  `json:"value,omitempty"`
+	Filters  []RuleFilter `json:"filters,omitempty"`
+}
+
+// RuleAggregation restricts a rule to people whose events reach a threshold, e.g. more than 2 visits.
+type RuleAggregation struct {
+	Type     string      `json:"type"`
+	Field    string      `json:"field,omitempty"`
+	Method   string      `json:"method,omitempty"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// NewAudienceRule returns a rule including the people matching any of the rules.
+func NewAudienceRule(rules ...AudienceSubRule) *AudienceRule {
+	return &AudienceRule{Inclusions: &AudienceRuleSet{Operator: "or", Rules: rules}}
+}
+
+// Exclude excludes the people matching any of the rules.
+func (ar *AudienceRule) Exclude(rules ...AudienceSubRule) *AudienceRule {
+	if ar.Exclusions == nil {
+		ar.Exclusions = &AudienceRuleSet{Operator: "or"}
+	}
+	ar.Exclusions.Rules = append(ar.Exclusions.Rules, rules...)
+
+	return ar
+}
+
+// PixelRule returns a rule matching the visitors of the pixel within the retention.
+func PixelRule(pixelID string, retention time.Duration) AudienceSubRule {
+	return AudienceSubRule{
+		EventSources:     []AudienceRuleEventSource{{ID: pixelID, Type: RuleEventSourcePixel}},
+		RetentionSeconds: int64(retention / time.Second),
+	}
+}
+
+// Where restricts the rule to events matching all filters.
+func (r AudienceSubRule) Where(filters ...RuleFilter) AudienceSubRule {
+	f := And(filters...)
+	r.Filter = &f
+
+	return r
+}
+
+// WithAggregation restricts the rule to people whose events reach the aggregation.
+func (r AudienceSubRule) WithAggregation(a RuleAggregation) AudienceSubRule {
+	r.Aggregation = &a
+
+	return r
+}
+
+// And matches if all filters match.
+func And(filters ...RuleFilter) RuleFilter {
+	return RuleFilter{Operator: "and", Filters: filters}
+}
+
+// Or matches if any filter matches.
+func Or(filters ...RuleFilter) RuleFilter {
+	return RuleFilter{Operator: "or", Filters: filters}
+}
+
+// FieldFilter compares a field, e.g. "url", "event" or a custom data key, with the value.
+func FieldFilter(field, operator string, value interface{}) RuleFilter {
+	return RuleFilter{Field: field, Operator: operator, Value: value}
+}
+
+// URLContains matches the events on URLs containing s, ignoring case.
+func URLContains(s string) RuleFilter {
+	return FieldFilter("url", "i_contains", s)
+}
+
+// EventIs matches the events with the name, e.g. "PageView" or "Purchase".
+func EventIs(name string) RuleFilter {
+	return FieldFilter("event", "eq", name)
+}
+
+// CountOf matches people with a number of events, e.g. CountOf(">", 2).
+func CountOf(operator string, n int) RuleAggregation {
+	return RuleAggregation{Type: "count", Method: "absolute", Operator: operator, Value: n}
+}
+
+// SumOf matches people with a sum of a custom data field, e.g. SumOf("value", ">=", 100).
+func SumOf(field, operator string, value float64) RuleAggregation {
+	return RuleAggregation{Type: "sum", Field: field, Method: "absolute", Operator: operator, Value: value}
+}
+
+// ParseAudienceRule parses the rule of a CustomAudience. Numbers are kept as json.Number.
+func ParseAudienceRule(s string) (*AudienceRule, error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	ar := &AudienceRule{}
+	err := d.Decode(ar)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audience rule: %w", err)
+	}
+
+	return ar, nil
+}
+
+// String returns the JSON encoding of the rule as expected by CustomAudience.Rule.
+// URLs and operators are not HTML escaped.
+func (ar AudienceRule) String() string {
+	buf := &bytes.Buffer{}
+	e := json.NewEncoder(buf)
+	e.SetEscapeHTML(false)
+	err := e.Encode(ar)
+	if err != nil {
+		return "err: " + err.Error()
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Validate checks the rule for errors Meta would reject it with.
+func (ar AudienceRule) Validate() error {
+	if ar.Inclusions == nil || len(ar.Inclusions.Rules) == 0 {
+		return errors.New("rule needs at least one inclusion")
+	}
+	err := ar.Inclusions.validate()
+	if err != nil {
+		return fmt.Errorf("inclusions: %w", err)
+	}
+	if ar.Exclusions != nil {
+		err = ar.Exclusions.validate()
+		if err != nil {
+			return fmt.Errorf("exclusions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (rs AudienceRuleSet) validate() error {
+	if rs.Operator != "or" && rs.Operator != "and" {
+		return fmt.Errorf("invalid operator '%s'", rs.Operator)
+	}
+	for i, r := range rs.Rules {
+		err := r.validate()
+		if err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (r AudienceSubRule) validate() error {
+	if len(r.EventSources) == 0 {
+		return errors.New("missing event sources")
+	}
+	limit := time.Duration(math.MaxInt64)
+	for _, es := range r.EventSources {
+		if es.ID == "" || es.Type == "" {
+			return fmt.Errorf("event source needs id and type: %+v", es)
+		}
+		l, ok := ruleRetentionLimits[es.Type]
+		if !ok {
+			l = MaxRuleRetention
+		}
+		if l < limit {
+			limit = l
+		}
+	}
+	if r.RetentionSeconds <= 0 || time.Duration(r.RetentionSeconds)*time.Second > limit {
+		return fmt.Errorf("retention of %d seconds is not within 1 second and %d days", r.RetentionSeconds, limit/(24*time.Hour))
+	}
+	if r.Filter != nil {
+		err := r.Filter.validate()
+		if err != nil {
+			return err
+		}
+	}
+	if r.Aggregation != nil {
+		return r.Aggregation.validate()
+	}
+
+	return nil
+}
+
+// Operator classes of filters, a field only supports the operators of its class.
+const (
+	ruleOperatorString = iota + 1
+	ruleOperatorNumber
+	ruleOperatorEquality
+	ruleOperatorList
+)
+
+var ruleFilterOperators = map[string]int{
+	"contains":       ruleOperatorString,
+	"not_contains":   ruleOperatorString,
+	"i_contains":     ruleOperatorString,
+	"i_not_contains": ruleOperatorString,
+	"starts_with":    ruleOperatorString,
+	"i_starts_with":  ruleOperatorString,
+	"regex_match":    ruleOperatorString,
+	"lt":             ruleOperatorNumber,
+	"lte":            ruleOperatorNumber,
+	"gt":             ruleOperatorNumber,
+	"gte":            ruleOperatorNumber,
+	"<":              ruleOperatorNumber,
+	"<=":             ruleOperatorNumber,
+	">":              ruleOperatorNumber,
+	">=":             ruleOperatorNumber,
+	"eq":             ruleOperatorEquality,
+	"neq":            ruleOperatorEquality,
+	"=":              ruleOperatorEquality,
+	"!=":             ruleOperatorEquality,
+	"is_any":         ruleOperatorList,
+	"is_not_any":     ruleOperatorList,
+	"i_is_any":       ruleOperatorList,
+	"i_is_not_any":   ruleOperatorList,
+}
+
+// ruleTextFields are the standard fields of pixel events, they can't be compared as numbers.
+var ruleTextFields = map[string]bool{
+	"url":    true,
+	"domain": true,
+	"path":   true,
+	"event":  true,
+}
+
+func (f RuleFilter) validate() error {
+	if len(f.Filters) > 0 {
+		if f.Operator != "and" && f.Operator != "or" {
+			return fmt.Errorf("invalid filter group operator '%s'", f.Operator)
+		} else if f.Field != "" || f.Value != nil {
+			return errors.New("filter group can't have a field or value")
+		}
+		for _, sub := range f.Filters {
+			err := sub.validate()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if f.Field == "" {
+		return fmt.Errorf("filter with operator '%s' needs a field or filters", f.Operator)
+	}
+	class, ok := ruleFilterOperators[f.Operator]
+	if !ok {
+		return fmt.Errorf("unknown filter operator '%s' for field '%s'", f.Operator, f.Field)
+	}
+	switch class {
+	case ruleOperatorString:
+		if _, ok := f.Value.(string); !ok {
+			return fmt.Errorf("operator '%s' of field '%s' needs a string value", f.Operator, f.Field)
+		}
+	case ruleOperatorNumber:
+		if ruleTextFields[f.Field] {
+			return fmt.Errorf("operator '%s' can't be used with field '%s'", f.Operator, f.Field)
+		} else if !isRuleNumber(f.Value) {
+			return fmt.Errorf("operator '%s' of field '%s' needs a number value", f.Operator, f.Field)
+		}
+	case ruleOperatorEquality:
+		if _, ok := f.Value.(string); !ok && !isRuleNumber(f.Value) {
+			return fmt.Errorf("operator '%s' of field '%s' needs a string or number value", f.Operator, f.Field)
+		}
+	case ruleOperatorList:
+		if !isRuleStrings(f.Value) {
+			return fmt.Errorf("operator '%s' of field '%s' needs a list of strings", f.Operator, f.Field)
+		}
+	}
+
+	return nil
+}
+
+var ruleAggregationTypes = map[string]bool{
+	"count":      true,
+	"sum":        true,
+	"avg":        true,
+	"min":        true,
+	"max":        true,
+	"time_spent": true,
+}
+
+func (a RuleAggregation) validate() error {
+	if !ruleAggregationTypes[a.Type] {
+		return fmt.Errorf("unknown aggregation type '%s'", a.Type)
+	} else if a.Type != "count" && a.Type != "time_spent" && a.Field == "" {
+		return fmt.Errorf("aggregation '%s' needs a field", a.Type)
+	} else if a.Method != "" && a.Method != "absolute" && a.Method != "percentile" {
+		return fmt.Errorf("unknown aggregation method '%s'", a.Method)
+	}
+	class := ruleFilterOperators[a.Operator]
+	if class != ruleOperatorNumber && class != ruleOperatorEquality {
+		return fmt.Errorf("invalid aggregation operator '%s'", a.Operator)
+	} else if !isRuleNumber(a.Value) {
+		return fmt.Errorf("aggregation '%s' needs a number value", a.Type)
+	}
+
+	return nil
+}
+
+func isRuleNumber(v interface{}) bool {
+	switch n := v.(type) {
+	case int, int64, float64, json.Number:
+		return true
+	case string:
+		_, err := strconv.ParseFloat(n, 64)
+
+		return err == nil
+	}
+
+	return false
+}
+
+func isRuleStrings(v interface{}) bool {
+	switch l := v.(type) {
+	case []string:
+		return len(l) > 0
+	case []interface{}:
+		for _, s := range l {
+			if _, ok := s.(string); !ok {
+				return false
+			}
+		}
+
+		return len(l) > 0
+	}
+
+	return false
+}
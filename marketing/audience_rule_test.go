@@ -0,0 +1,71 @@
+package marketing
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAudienceRuleBuilder(t *testing.T) {
+	day := 24 * time.Hour
+	r := NewAudienceRule(
+		PixelRule("123", 30*day).Where(URLContains("shoes"), EventIs("ViewContent")).WithAggregation(CountOf(">", 2)),
+	).Exclude(
+		PixelRule("123", 7*day).Where(EventIs("Purchase")),
+	)
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"inclusions":{"operator":"or","rules":[{"event_sources":[{"id":"123","type":"pixel"}],"retention_seconds":2592000,` +
+		`"filter":{"operator":"and","filters":[{"field":"url","operator":"i_contains","value":"shoes"},{"field":"event","operator":"eq","value":"ViewContent"}]},` +
+		`"aggregation":{"type":"count","method":"absolute","operator":">","value":2}}]},` +
+		`"exclusions":{"operator":"or","rules":[{"event_sources":[{"id":"123","type":"pixel"}],"retention_seconds":604800,` +
+		`"filter":{"operator":"and","filters":[{"field":"event","operator":"eq","value":"Purchase"}]}}]}}`
+	if r.String() != want {
+		t.Fatalf("rule = %s\nwant %s", r, want)
+	}
+}
+
+func TestParseAudienceRuleRoundTrip(t *testing.T) {
+	rule := `{"inclusions":{"operator":"or","rules":[{"event_sources":[{"id":"123","type":"pixel"}],"retention_seconds":15552000,` +
+		`"filter":{"operator":"and","filters":[{"operator":"or","filters":[{"field":"url","operator":"i_contains","value":"/cart"},{"field":"path","operator":"i_is_any","value":["/a","/b"]}]},` +
+		`{"field":"value","operator":"gte","value":99.5}]},"aggregation":{"type":"sum","field":"value","method":"absolute","operator":">=","value":100},"template":"VISITORS_BY_URL"}]}}`
+	r, err := ParseAudienceRule(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got, want interface{}
+	if err := json.Unmarshal([]byte(r.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(rule), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip changed rule\n got %s\nwant %s", r, rule)
+	}
+}
+
+func TestAudienceRuleValidate(t *testing.T) {
+	for name, r := range map[string]*AudienceRule{
+		"no inclusions":     {},
+		"retention":         NewAudienceRule(PixelRule("1", 181*24*time.Hour)),
+		"no event source":   NewAudienceRule(AudienceSubRule{RetentionSeconds: 60}),
+		"unknown operator":  NewAudienceRule(PixelRule("1", time.Hour).Where(FieldFilter("url", "like", "x"))),
+		"number on url":     NewAudienceRule(PixelRule("1", time.Hour).Where(FieldFilter("url", "gt", 1))),
+		"string operator":   NewAudienceRule(PixelRule("1", time.Hour).Where(FieldFilter("url", "i_contains", 1))),
+		"list operator":     NewAudienceRule(PixelRule("1", time.Hour).Where(FieldFilter("path", "is_any", "/a"))),
+		"sum without field": NewAudienceRule(PixelRule("1", time.Hour).WithAggregation(RuleAggregation{Type: "sum", Operator: ">", Value: 1})),
+		"exclusion":         NewAudienceRule(PixelRule("1", time.Hour)).Exclude(PixelRule("1", 0)),
+	} {
+		if err := r.Validate(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+// AudienceSnapshot contains the rows last pushed to an audience. The values of hashed schema keys are
+// stored as SHA-256 hashes, even for raw uploads. MADID and EXTERN_ID are stored normalized like they are
+// uploaded, as Meta only matches them unhashed.
+type AudienceSnapshot struct {
+	Schema  []string        `json:"schema"`
+	Rows    [][]interface{} `json:"rows"`
+	Updated time.Time       `json:"updated"`
+}
+
+// AudienceSnapshotStore persists AudienceSnapshots by audience id. Load returns nil if there is no snapshot.
+type AudienceSnapshotStore interface {
+	Load(audienceID string) (*AudienceSnapshot, error)
+	Save(audienceID string, s *AudienceSnapshot) error
+}
+
+// FileAudienceSnapshotStore stores each AudienceSnapshot as JSON file in Dir.
+type FileAudienceSnapshotStore struct {
+	Dir string
+}
+
+// Load implements AudienceSnapshotStore.
+func (fs FileAudienceSnapshotStore) Load(audienceID string) (*AudienceSnapshot, error) {
+	b, err := os.ReadFile(storePath(fs.Dir, audienceID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	s := &AudienceSnapshot{}
+	err = json.Unmarshal(b, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Save implements AudienceSnapshotStore, the file is replaced atomically.
+func (fs FileAudienceSnapshotStore) Save(audienceID string, s *AudienceSnapshot) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(storePath(fs.Dir, audienceID), b)
+}
+
+// SyncReport is the result of AudienceService.Sync. Added and Removed are nil if there was nothing to upload.
+type SyncReport struct {
+	Added     *UploadReport
+	Removed   *UploadReport
+	Unchanged int
+	// Skipped is the number of desired rows without value to match.
+	Skipped uint64
+}
+
+// SyncIDs makes the mobile advertiser ids read from c the members of the audience, see Sync.
+func (as *AudienceService) SyncIDs(ctx context.Context, audienceID string, c <-chan string, snapshots AudienceSnapshotStore) (*SyncReport, error) {
+	return as.Sync(ctx, audienceID, CustomerUpload{Schema: []string{CustomerMadID}}, madIDRows(c), snapshots, UploadOptions{})
+}
+
+// Sync makes the rows the members of the audience by only uploading the difference to the snapshot of the last sync.
+// Rows missing from the snapshot are added, rows missing from the desired rows are removed.
+// The snapshot is saved only after both uploads succeeded, so a failed sync is retried completely.
+// Without snapshot all rows are added, members uploaded by other means are never removed.
+// The mode of opts is ignored, a Store in opts can resume failed uploads as long as rows and snapshot did not change.
+func (as *AudienceService) Sync(ctx context.Context, audienceID string, u CustomerUpload, rows CustomerRows, snapshots AudienceSnapshotStore, opts UploadOptions) (*SyncReport, error) {
+	err := u.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := snapshots.Load(audienceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshot of audience %s: %w", audienceID, err)
+	}
+	if prev == nil {
+		prev = &AudienceSnapshot{Schema: u.Schema}
+	} else if strings.Join(prev.Schema, ",") != strings.Join(u.Schema, ",") {
+		return nil, fmt.Errorf("snapshot of audience %s has schema %v, not %v", audienceID, prev.Schema, u.Schema)
+	}
+	old, err := keyRows(u.hashRows(prev.Rows))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SyncReport{}
+	// desired contains the values to upload and hashed the values to store, both by the key of the hashed values
+	desired, hashed := map[string][]interface{}{}, map[string][]interface{}{}
+	for {
+		row, ok, err := rows()
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+		values, ok := u.values(row)
+		if !ok {
+			report.Skipped++
+
+			continue
+		}
+		h := u.hashValues(values)
+		k, err := rowKey(h)
+		if err != nil {
+			return nil, err
+		}
+		desired[k], hashed[k] = values, h
+	}
+
+	var added, removed [][]interface{}
+	for _, k := range sortedKeys(desired) {
+		if _, ok := old[k]; ok {
+			report.Unchanged++
+		} else {
+			added = append(added, desired[k])
+		}
+	}
+	for _, k := range sortedKeys(old) {
+		if _, ok := desired[k]; !ok {
+			removed = append(removed, old[k])
+		}
+	}
+
+	if len(removed) > 0 {
+		// the snapshot only knows the hashes of the hashed keys
+		hu := u
+		hu.IsRaw = false
+		opts.Mode = UploadRemove
+		report.Removed, err = as.upload(ctx, audienceID, hu, valueBatches(removed), opts)
+		if err != nil {
+			return report, err
+		}
+	}
+	if len(added) > 0 {
+		opts.Mode = UploadAdd
+		report.Added, err = as.upload(ctx, audienceID, u, valueBatches(added), opts)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	next := &AudienceSnapshot{Schema: u.Schema, Rows: make([][]interface{}, 0, len(desired)), Updated: time.Now()}
+	for _, k := range sortedKeys(hashed) {
+		next.Rows = append(next.Rows, hashed[k])
+	}
+	err = snapshots.Save(audienceID, next)
+	if err != nil {
+		return report, fmt.Errorf("failed to save snapshot of audience %s: %w", audienceID, err)
+	}
+
+	return report, nil
+}
+
+// hashValues returns the values with the values of hashed keys hashed, values that are already hashed are kept.
+func (u CustomerUpload) hashValues(values []interface{}) []interface{} {
+	res := make([]interface{}, len(values))
+	for i, v := range values {
+		res[i] = v
+		if s, ok := v.(string); ok && i < len(u.Schema) && customerFields[u.Schema[i]].hashed {
+			res[i] = types.Hash(func(v string) string { return v }, s)
+		}
+	}
+
+	return res
+}
+
+// hashRows hashes the rows of snapshots written before the values of hashed keys were hashed.
+func (u CustomerUpload) hashRows(rows [][]interface{}) [][]interface{} {
+	res := make([][]interface{}, len(rows))
+	for i, values := range rows {
+		res[i] = u.hashValues(values)
+	}
+
+	return res
+}
+
+// rowKey returns the JSON encoding of the values, which is the same for rows read from a snapshot.
+func rowKey(values []interface{}) (string, error) {
+	b, err := json.Marshal(values)
+
+	return string(b), err
+}
+
+func keyRows(rows [][]interface{}) (map[string][]interface{}, error) {
+	res := make(map[string][]interface{}, len(rows))
+	for _, values := range rows {
+		k, err := rowKey(values)
+		if err != nil {
+			return nil, err
+		}
+		res[k] = values
+	}
+
+	return res, nil
+}
+
+// sortedKeys returns the keys of m in order, so the batches of a sync are the same when it gets resumed.
+func sortedKeys(m map[string][]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// valueBatches returns a batchReader over rows that were already normalized.
+func valueBatches(rows [][]interface{}) batchReader {
+	return func(*uint64) ([][]interface{}, bool, error) {
+		n := len(rows)
+		if n > BatchMaxIDsSequence {
+			n = BatchMaxIDsSequence
+		}
+		batch := rows[:n]
+		rows = rows[n:]
+
+		return batch, len(rows) > 0, nil
+	}
+}
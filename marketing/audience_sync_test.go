@@ -0,0 +1,141 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+func TestSyncIDs(t *testing.T) {
+	var fail bool
+	sent := map[string][]string{}
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceSyncRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		req := editCustomersRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if fail {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     "400 Bad Request",
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`)),
+				Request:    request,
+			}, nil
+		}
+		for _, row := range req.Payload.Data {
+			sent[request.Method] = append(sent[request.Method], fmt.Sprint(row...))
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"num_received":%d,"num_invalid_entries":0}`, len(req.Payload.Data)))),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+	store := FileAudienceSnapshotStore{Dir: t.TempDir()}
+
+	sync := func(ids ...string) (*SyncReport, error) {
+		c := make(chan string, len(ids))
+		for _, id := range ids {
+			c <- id
+		}
+		close(c)
+
+		return service.SyncIDs(context.Background(), "aud-1", c, store)
+	}
+
+	report, err := sync("A", "b", "b", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sent) != "map[POST:[a b]]" || report.Added.Received != 2 || report.Removed != nil {
+		t.Fatalf("unexpected first sync %v %+v", sent, report)
+	}
+
+	fail = true
+	if _, err := sync("c"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	fail = false
+	sent = map[string][]string{}
+	report, err = sync("b", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sent) != "map[DELETE:[a] POST:[c]]" || report.Unchanged != 1 || report.Added.Total != 1 || report.Removed.Total != 1 {
+		t.Fatalf("unexpected second sync %v %+v", sent, report)
+	}
+
+	sent = map[string][]string{}
+	report, err = sync("c", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 0 || report.Unchanged != 2 || report.Added != nil || report.Removed != nil {
+		t.Fatalf("unexpected unchanged sync %v %+v", sent, report)
+	}
+
+	snapshot, err := store.Load("aud-1")
+	if err != nil || len(snapshot.Rows) != 2 {
+		t.Fatalf("unexpected snapshot %+v %v", snapshot, err)
+	}
+	if fmt.Sprint(snapshot.Rows) != "[[b] [c]]" {
+		t.Fatalf("snapshot rows = %v, want the uploaded ids", snapshot.Rows)
+	}
+}
+
+func TestSyncHashesSnapshot(t *testing.T) {
+	var requests []editCustomersRequest
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceSyncRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		req := editCustomersRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		requests = append(requests, req)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"num_received":%d,"num_invalid_entries":0}`, len(req.Payload.Data)))),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+	store := FileAudienceSnapshotStore{Dir: t.TempDir()}
+	u := CustomerUpload{Schema: []string{CustomerEmail, CustomerExternID}, IsRaw: true}
+
+	rows := CustomerRowsFromSlice([]CustomerRow{{Email: "John@Example.com", ExternID: "42"}})
+	if _, err := service.Sync(context.Background(), "aud-1", u, rows, store, UploadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.Sync(context.Background(), "aud-1", u, CustomerRowsFromSlice(nil), store, UploadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := types.Hash(types.NormalizeEmail, "john@example.com")
+	if len(requests) != 2 || fmt.Sprint(requests[0].Payload.Data) != "[[john@example.com 42]]" || !requests[0].Payload.IsRaw {
+		t.Fatalf("unexpected add %+v", requests)
+	}
+	if fmt.Sprint(requests[1].Payload.Data) != "[["+hash+" 42]]" || requests[1].Payload.IsRaw {
+		t.Fatalf("unexpected removal %+v", requests[1])
+	}
+}
+
+type audienceSyncRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f audienceSyncRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,415 @@
+package marketing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// UploadMode selects the endpoint of a customer list upload.
+type UploadMode string
+
+// Modes of AudienceService.Upload.
+const (
+	UploadAdd     UploadMode = "add"
+	UploadRemove  UploadMode = "remove"
+	UploadReplace UploadMode = "replace"
+)
+
+// UploadOptions configure AudienceService.Upload.
+type UploadOptions struct {
+	Mode UploadMode
+	// Concurrency is the number of batches sent in parallel, defaults to 1.
+	// The last batch is always sent after all other batches were received.
+	Concurrency int
+	// Store persists the progress of the session. If an upload fails, calling Upload again
+	// with the same rows resumes the session and skips the batches that were already received.
+	Store UploadStateStore
+	// WaitInterval is how often ReplaceUsers polls the session until Meta processed it, defaults to 10s.
+	WaitInterval time.Duration
+}
+
+// UploadReport contains the totals of an upload session.
+type UploadReport struct {
+	SessionID uint32
+	Batches   int
+	// Total is the number of rows sent, Received and Invalid are summed up from the responses of all batches.
+	Total    uint64
+	Received uint64
+	// Invalid contains the rows Meta rejected and the rows skipped because they had no value to match.
+	Invalid uint64
+	// Resumed is the number of batches that were received in a previous attempt.
+	Resumed int
+}
+
+// Err returns an *UploadError if not all rows were received or rows were invalid.
+func (r *UploadReport) Err() error {
+	if r.Total != r.Received || r.Invalid > 0 {
+		return &UploadError{
+			Total:    r.Total,
+			Received: r.Received,
+			Failed:   r.Invalid,
+		}
+	}
+
+	return nil
+}
+
+// UploadState is the persisted progress of an upload session.
+type UploadState struct {
+	AudienceID string                    `json:"audience_id"`
+	Mode       UploadMode                `json:"mode"`
+	Schema     []string                  `json:"schema"`
+	SessionID  uint32                    `json:"session_id"`
+	Batches    map[int]UploadBatchResult `json:"batches"`
+}
+
+// UploadBatchResult is the response to a single batch of a session.
+type UploadBatchResult struct {
+	Rows     int    `json:"rows"`
+	Received uint64 `json:"received"`
+	Invalid  uint64 `json:"invalid"`
+}
+
+// UploadStateStore persists UploadStates by key. Load returns nil if there is no state for key.
+type UploadStateStore interface {
+	Load(key string) (*UploadState, error)
+	Save(key string, s *UploadState) error
+	Delete(key string) error
+}
+
+// FileUploadStateStore stores each UploadState as JSON file in Dir.
+type FileUploadStateStore struct {
+	Dir string
+}
+
+func (fs FileUploadStateStore) path(key string) string {
+	return storePath(fs.Dir, key)
+}
+
+// Load implements UploadStateStore.
+func (fs FileUploadStateStore) Load(key string) (*UploadState, error) {
+	b, err := os.ReadFile(fs.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	s := &UploadState{}
+	err = json.Unmarshal(b, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Save implements UploadStateStore, the file is replaced atomically.
+func (fs FileUploadStateStore) Save(key string, s *UploadState) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(fs.path(key), b)
+}
+
+// writeFileAtomic writes b to a temporary file and renames it to path.
+func writeFileAtomic(path string, b []byte) error {
+	tmp := path + ".tmp"
+	err := os.WriteFile(tmp, b, 0o600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// storePath returns the path of the file for key in dir.
+func storePath(dir, key string) string {
+	return filepath.Join(dir, strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(key)+".json")
+}
+
+// Delete implements UploadStateStore.
+func (fs FileUploadStateStore) Delete(key string) error {
+	err := os.Remove(fs.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// AddCustomers adds the rows to a customer list audience in batches of BatchMaxIDsSequence rows.
+// Rows without any value to match are skipped and counted as failed.
+func (as *AudienceService) AddCustomers(ctx context.Context, audienceID string, u CustomerUpload, rows CustomerRows) error {
+	r, err := as.Upload(ctx, audienceID, u, rows, UploadOptions{Mode: UploadAdd})
+	if err != nil {
+		return err
+	}
+
+	return r.Err()
+}
+
+// RemoveCustomers removes the rows from a customer list audience.
+func (as *AudienceService) RemoveCustomers(ctx context.Context, audienceID string, u CustomerUpload, rows CustomerRows) error {
+	r, err := as.Upload(ctx, audienceID, u, rows, UploadOptions{Mode: UploadRemove})
+	if err != nil {
+		return err
+	}
+
+	return r.Err()
+}
+
+// Upload sends the rows to a customer list audience in a session of batches of BatchMaxIDsSequence rows.
+// The progress is reported by a fb.Stat in the StatsContainer of the service with the key "<audienceID>/<mode>",
+// only one upload per audience and mode can run at a time. A report is returned even if the upload fails.
+func (as *AudienceService) Upload(ctx context.Context, audienceID string, u CustomerUpload, rows CustomerRows, opts UploadOptions) (*UploadReport, error) {
+	err := u.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return as.upload(ctx, audienceID, u, func(skipped *uint64) ([][]interface{}, bool, error) {
+		return readCustomers(u, rows, skipped)
+	}, opts)
+}
+
+// batchReader returns the next batch of values, more is false once there are no batches left.
+// Rows without value to match are counted in skipped.
+type batchReader func(skipped *uint64) (batch [][]interface{}, more bool, err error)
+
+func (as *AudienceService) upload(ctx context.Context, audienceID string, u CustomerUpload, read batchReader, opts UploadOptions) (*UploadReport, error) {
+	send := as.c.PostJSON
+	route := fb.NewRoute(as.v.Name, "/%s/users", audienceID).String()
+	switch opts.Mode {
+	case UploadAdd, "":
+		opts.Mode = UploadAdd
+	case UploadRemove:
+		send = as.c.DeleteJSON
+	case UploadReplace:
+		route = fb.NewRoute(as.v.Name, "/%s/usersreplace", audienceID).String()
+	default:
+		return nil, fmt.Errorf("unknown upload mode '%s'", opts.Mode)
+	}
+
+	key := audienceID + "/" + string(opts.Mode)
+	stat := as.StatsContainer.AddStats(key)
+	if stat == nil {
+		return nil, fmt.Errorf("audience %s is already being uploaded to", key)
+	}
+	defer as.StatsContainer.RemoveStats(key)
+
+	state, err := as.uploadState(key, audienceID, u, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	up := &uploader{
+		send:   send,
+		route:  route,
+		u:      u,
+		opts:   opts,
+		key:    key,
+		state:  state,
+		stat:   stat,
+		report: &UploadReport{SessionID: state.SessionID},
+	}
+	err = up.run(ctx, read)
+	if err != nil {
+		return up.report, err
+	}
+
+	if opts.Store != nil {
+		err = opts.Store.Delete(key)
+	}
+
+	return up.report, err
+}
+
+// uploadState resumes the stored session for key, or starts a new one.
+func (as *AudienceService) uploadState(key, audienceID string, u CustomerUpload, opts UploadOptions) (*UploadState, error) {
+	if opts.Store != nil {
+		s, err := opts.Store.Load(key)
+		if err != nil {
+			return nil, err
+		} else if s != nil && s.AudienceID == audienceID && s.Mode == opts.Mode && strings.Join(s.Schema, ",") == strings.Join(u.Schema, ",") {
+			if s.Batches == nil {
+				s.Batches = map[int]UploadBatchResult{}
+			}
+
+			return s, nil
+		}
+	}
+
+	bigN, err := rand.Int(rand.Reader, big.NewInt(math.MaxUint32))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session ID: %w", err)
+	}
+
+	return &UploadState{
+		AudienceID: audienceID,
+		Mode:       opts.Mode,
+		Schema:     u.Schema,
+		SessionID:  uint32(bigN.Int64()),
+		Batches:    map[int]UploadBatchResult{},
+	}, nil
+}
+
+// uploader runs a single upload session.
+type uploader struct {
+	send  func(context.Context, string, interface{}, interface{}) error
+	route string
+	u     CustomerUpload
+	opts  UploadOptions
+	key   string
+	stat  *fb.Stat
+
+	mu     sync.Mutex
+	state  *UploadState
+	report *UploadReport
+	// skipped is the number of rows without value already counted in report.Invalid
+	skipped uint64
+}
+
+func (up *uploader) run(ctx context.Context, read batchReader) error {
+	concurrency := up.opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var firstErr error
+	var errOnce sync.Once
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var skipped uint64
+	batch, more, err := read(&skipped)
+	for seq := 1; err == nil && len(batch) > 0 && ctx.Err() == nil; seq++ {
+		// read ahead to know whether this is the last batch
+		var next [][]interface{}
+		if more {
+			next, more, err = read(&skipped)
+			if err != nil {
+				break
+			}
+		}
+		last := len(next) == 0
+		up.addRead(len(batch), skipped)
+
+		if res, ok := up.done(seq); ok {
+			up.addResult(res, true)
+		} else if last {
+			// the last batch closes the session, so all other batches have to be received first
+			wg.Wait()
+			if firstErr == nil {
+				if err := up.sendBatch(ctx, seq, batch, true); err != nil {
+					fail(err)
+				}
+			}
+		} else {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(seq int, batch [][]interface{}) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				if err := up.sendBatch(ctx, seq, batch, false); err != nil {
+					fail(err)
+				}
+			}(seq, batch)
+		}
+		batch = next
+	}
+	wg.Wait()
+
+	if err != nil {
+		return err
+	}
+
+	return firstErr
+}
+
+func (up *uploader) done(seq int) (UploadBatchResult, bool) {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	res, ok := up.state.Batches[seq]
+
+	return res, ok
+}
+
+// addRead counts the rows read so far, skipped is the running total of rows without value.
+func (up *uploader) addRead(rows int, skipped uint64) {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	up.report.Batches++
+	up.report.Total += uint64(rows)
+	up.report.Invalid += skipped - up.skipped
+	up.skipped = skipped
+	up.stat.SetProgress(up.report.Received, up.report.Total)
+}
+
+func (up *uploader) addResult(res UploadBatchResult, resumed bool) {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	up.report.Received += res.Received
+	up.report.Invalid += res.Invalid
+	if resumed {
+		up.report.Resumed++
+	}
+	up.stat.SetProgress(up.report.Received, up.report.Total)
+}
+
+func (up *uploader) sendBatch(ctx context.Context, seq int, batch [][]interface{}, last bool) error {
+	req := editCustomersRequest{
+		Session: uploadSession{
+			SessionID:     up.state.SessionID,
+			BatchSequence: seq,
+			LastBatchFlag: last,
+		},
+		Payload: customerPayload{
+			Schema:     up.u.Schema,
+			IsRaw:      up.u.IsRaw,
+			DataSource: up.u.DataSource,
+			Data:       batch,
+		},
+	}
+	res := &editAudienceIDsResponse{}
+	err := up.send(ctx, up.route, req, res)
+	if err != nil {
+		return fmt.Errorf("batch %d of session %d: %w", seq, up.state.SessionID, err)
+	}
+
+	result := UploadBatchResult{Rows: len(batch), Received: res.NumReceived, Invalid: res.NumInvalidEntries}
+	up.addResult(result, false)
+
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	up.state.Batches[seq] = result
+	if up.opts.Store != nil {
+		return up.opts.Store.Save(up.key, up.state)
+	}
+
+	return nil
+}
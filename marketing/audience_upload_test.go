@@ -0,0 +1,140 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func uploadTestRows(n int) []CustomerRow {
+	rows := make([]CustomerRow, n)
+	for i := range rows {
+		rows[i] = CustomerRow{ExternID: fmt.Sprintf("user-%d", i)}
+	}
+
+	return rows
+}
+
+func TestUploadConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	var sequences []int
+	var lastSeq int
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceUploadRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		req := editCustomersRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		mu.Lock()
+		if req.Session.LastBatchFlag {
+			if len(sequences) != 3 {
+				t.Errorf("last batch sent after %d batches", len(sequences))
+			}
+			lastSeq = req.Session.BatchSequence
+		}
+		sequences = append(sequences, req.Session.BatchSequence)
+		mu.Unlock()
+
+		// Meta rejects one row of every batch
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"num_received":%d,"num_invalid_entries":1}`, len(req.Payload.Data)-1))),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+
+	rows := append(uploadTestRows(3*BatchMaxIDsSequence+5), CustomerRow{})
+	u := CustomerUpload{Schema: []string{CustomerExternID}}
+	report, err := service.Upload(context.Background(), "aud-1", u, CustomerRowsFromSlice(rows), UploadOptions{Concurrency: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sequences) != 4 || lastSeq != 4 {
+		t.Fatalf("unexpected batches %v", sequences)
+	}
+	if report.Batches != 4 || report.Total != 3*BatchMaxIDsSequence+5 || report.Received != 3*BatchMaxIDsSequence+1 || report.Invalid != 5 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if len(service.Stats()) != 0 {
+		t.Fatal("expected stats to be removed")
+	}
+}
+
+func TestUploadResume(t *testing.T) {
+	var sessions []uint32
+	var sequences []int
+	fail := true
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: audienceUploadRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if request.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s", request.Method)
+		}
+		req := editCustomersRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		sessions = append(sessions, req.Session.SessionID)
+		sequences = append(sequences, req.Session.BatchSequence)
+		if fail && req.Session.BatchSequence == 2 {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     "400 Bad Request",
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`)),
+				Request:    request,
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"num_received":%d,"num_invalid_entries":0}`, len(req.Payload.Data)))),
+			Request:    request,
+		}, nil
+	})}
+	service := &AudienceService{c: client, v: Version{Name: "v24.0"}, StatsContainer: fb.NewStatsContainer()}
+
+	rows := uploadTestRows(2*BatchMaxIDsSequence + 1)
+	u := CustomerUpload{Schema: []string{CustomerExternID}}
+	opts := UploadOptions{Mode: UploadRemove, Store: FileUploadStateStore{Dir: t.TempDir()}}
+	report, err := service.Upload(context.Background(), "aud-1", u, CustomerRowsFromSlice(rows), opts)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if report.Received != BatchMaxIDsSequence {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	fail = false
+	sessions, sequences = nil, nil
+	report, err = service.Upload(context.Background(), "aud-1", u, CustomerRowsFromSlice(rows), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sequences) != "[2 3]" || sessions[0] != report.SessionID {
+		t.Fatalf("unexpected resumed batches %v of sessions %v", sequences, sessions)
+	}
+	if report.Resumed != 1 || report.Total != 2*BatchMaxIDsSequence+1 || report.Received != report.Total || report.Err() != nil {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	state, err := opts.Store.Load("aud-1/remove")
+	if err != nil || state != nil {
+		t.Fatalf("expected state to be deleted, got %+v %v", state, err)
+	}
+}
+
+type audienceUploadRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f audienceUploadRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -16,6 +16,8 @@ import (
 type graphBatchRequest struct {
 	Method      string `json:"method"`
 	RelativeURL string `json:"relative_url"`
+	// Body contains the url encoded parameters of POST requests.
+	Body string `json:"body,omitempty"`
 }
 
 type graphBatchResponse struct {
@@ -31,6 +33,12 @@ func (ps *PostService) getBatch(ctx context.Context, relativeURLs []string) ([]g
 			RelativeURL: relativeURL,
 		}
 	}
+
+	return doBatch(ctx, ps.c, ps.v, batch)
+}
+
+// doBatch sends the requests in a single batch request and returns a response for each request.
+func doBatch(ctx context.Context, c *fb.Client, v Version, batch []graphBatchRequest) ([]graphBatchResponse, error) {
 	batchJSON, err := json.Marshal(batch)
 	if err != nil {
 		return nil, err
@@ -40,7 +48,7 @@ func (ps *PostService) getBatch(ctx context.Context, relativeURLs []string) ([]g
 	request, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		fb.NewRoute(Version, "/").String(),
+		fb.NewRoute(v.Name, "/").String(),
 		strings.NewReader(form.Encode()),
 	)
 	if err != nil {
@@ -48,7 +56,7 @@ func (ps *PostService) getBatch(ctx context.Context, relativeURLs []string) ([]g
 	}
 	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	response, err := ps.c.Do(request)
+	response, err := c.Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -69,8 +77,8 @@ func (ps *PostService) getBatch(ctx context.Context, relativeURLs []string) ([]g
 	if err := json.Unmarshal(body, &batchResponses); err != nil {
 		return nil, err
 	}
-	if len(batchResponses) != len(relativeURLs) {
-		return nil, fmt.Errorf("received %d Facebook batch responses for %d requests", len(batchResponses), len(relativeURLs))
+	if len(batchResponses) != len(batch) {
+		return nil, fmt.Errorf("received %d Facebook batch responses for %d requests", len(batchResponses), len(batch))
 	}
 	return batchResponses, nil
 }
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -56,15 +56,16 @@ func toStrings(status []EffectiveStatus) []string {
 // CampaignService works with campaigns.
 type CampaignService struct {
 	c *fb.Client
+	v Version
 }
 
 // Get returns a single campaign.
 func (cs *CampaignService) Get(ctx context.Context, id string, fields ...string) (*Campaign, error) {
 	if len(fields) == 0 {
-		fields = campaignFields
+		fields = cs.v.campaignFields()
 	}
 	res := &Campaign{}
-	err := cs.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Fields(fields...).String(), res)
+	err := cs.c.GetJSON(ctx, fb.NewRoute(cs.v.Name, "/%s", id).Fields(fields...).String(), res)
 	if err != nil {
 		if fb.IsNotFound(err) {
 			return nil, nil
@@ -84,9 +85,13 @@ func (cs *CampaignService) Create(ctx context.Context, c Campaign) (string, erro
 		return "", errors.New("cannot create campaign without account id")
 	}
 
+	payload, err := cs.v.campaignPayload(c)
+	if err != nil {
+		return "", err
+	}
 	res := &fb.MinimalResponse{}
-	url := fb.NewRoute(Version, "/act_%s/campaigns", c.AccountID).String()
-	err := cs.c.PostJSON(ctx, url, c, res)
+	url := fb.NewRoute(cs.v.Name, "/act_%s/campaigns", c.AccountID).String()
+	err = cs.c.PostJSON(ctx, url, payload, res)
 	if err != nil {
 		return "", fmt.Errorf("could not POST to %q: %w", url, err)
 	} else if err = res.GetError(); err != nil {
@@ -104,8 +109,12 @@ func (cs *CampaignService) Update(ctx context.Context, c Campaign) error {
 		return errors.New("cannot update a campaign without id")
 	}
 
+	payload, err := cs.v.campaignPayload(c)
+	if err != nil {
+		return err
+	}
 	res := &fb.MinimalResponse{}
-	err := cs.c.PostJSON(ctx, fb.NewRoute(Version, "/%s", c.ID).String(), c, res)
+	err = cs.c.PostJSON(ctx, fb.NewRoute(cs.v.Name, "/%s", c.ID).String(), payload, res)
 	if err != nil {
 		return err
 	} else if err = res.GetError(); err != nil {
@@ -117,6 +126,16 @@ func (cs *CampaignService) Update(ctx context.Context, c Campaign) error {
 	return nil
 }
 
+// Delete deletes a campaign together with its adsets and ads.
+func (cs *CampaignService) Delete(ctx context.Context, id string) error {
+	return cs.c.Delete(ctx, fb.NewRoute(cs.v.Name, "/%s", id).String())
+}
+
+// Archive sets the status of a campaign to ARCHIVED.
+func (cs *CampaignService) Archive(ctx context.Context, id string) error {
+	return setStatus(ctx, cs.c, cs.v, id, StatusArchived)
+}
+
 // List creates a new CampaignListCall.
 func (cs *CampaignService) List(act string) *CampaignListCall {
 	return cs.ListByEffectiveStatus(act, DefaultEffectiveStatuses...)
@@ -126,19 +145,10 @@ func (cs *CampaignService) List(act string) *CampaignListCall {
 // effective_status values. If no statuses are provided, the filter is omitted.
 // Behavior otherwise matches List.
 func (cs *CampaignService) ListByEffectiveStatus(act string, statuses ...EffectiveStatus) *CampaignListCall {
-	rb := fb.NewRoute(Version, "/act_%s/campaigns", act).
+	rb := fb.NewRoute(cs.v.Name, "/act_%s/campaigns", act).
 		Fields(campaignFieldsShort...).
-		Limit(1000)
-
-	if len(statuses) > 0 {
-		rb = rb.Filtering(
-			fb.Filter{
-				Field:    "effective_status",
-				Operator: "IN",
-				Value:    toStrings(statuses),
-			},
-		)
-	}
+		Limit(1000).
+		EffectiveStatus(toStrings(statuses)...)
 
 	return &CampaignListCall{
 		RouteBuilder: rb, c: cs.c,
@@ -0,0 +1,289 @@
+package marketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// NoSpendCap is the spend cap value removing the spend cap of a campaign.
+const NoSpendCap = 922337203685478
+
+// Value types of budget schedules.
+const (
+	BudgetValueAbsolute   = "ABSOLUTE"
+	BudgetValueMultiplier = "MULTIPLIER"
+)
+
+// defaultCampaignBidStrategy is used when switching to a campaign budget without bid strategy.
+const defaultCampaignBidStrategy = "LOWEST_COST_WITHOUT_CAP"
+
+// BudgetSchedule is a high demand period increasing the budget of a campaign, see
+// https://developers.facebook.com/docs/marketing-api/reference/high-demand-period/
+type BudgetSchedule struct {
+	ID              string  `json:"id,omitempty"`
+	BudgetValue     uint64  `json:"budget_value,omitempty"`
+	BudgetValueType string  `json:"budget_value_type,omitempty"`
+	RecurrenceType  string  `json:"recurrence_type,omitempty"`
+	TimeStart       fb.Time `json:"time_start"`
+	TimeEnd         fb.Time `json:"time_end"`
+}
+
+func (bs BudgetSchedule) validate() error {
+	if bs.BudgetValue == 0 {
+		return errors.New("missing budget value")
+	} else if bs.BudgetValueType != BudgetValueAbsolute && bs.BudgetValueType != BudgetValueMultiplier {
+		return fmt.Errorf("unknown budget value type '%s'", bs.BudgetValueType)
+	} else if !time.Time(bs.TimeEnd).After(time.Time(bs.TimeStart)) {
+		return errors.New("budget schedule must end after it starts")
+	}
+
+	return nil
+}
+
+// ListBudgetSchedules returns the budget schedules of a campaign.
+func (cs *CampaignService) ListBudgetSchedules(ctx context.Context, campaignID string) ([]BudgetSchedule, error) {
+	res := []BudgetSchedule{}
+	err := cs.c.GetList(ctx, fb.NewRoute(cs.v.Name, "/%s/budget_schedules", campaignID).
+		Fields("id", "budget_value", "budget_value_type", "recurrence_type", "time_start", "time_end").
+		Limit(100).
+		String(), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CreateBudgetSchedule creates a budget schedule of a campaign and returns its id.
+func (cs *CampaignService) CreateBudgetSchedule(ctx context.Context, campaignID string, bs BudgetSchedule) (string, error) {
+	if bs.ID != "" {
+		return "", fmt.Errorf("cannot create budget schedule that already exists: %s", bs.ID)
+	}
+	err := bs.validate()
+	if err != nil {
+		return "", err
+	}
+
+	res := &fb.MinimalResponse{}
+	err = cs.c.PostJSON(ctx, fb.NewRoute(cs.v.Name, "/%s/budget_schedules", campaignID).String(), bs, res)
+	if err != nil {
+		return "", err
+	} else if err = res.GetError(); err != nil {
+		return "", err
+	} else if res.ID == "" {
+		return "", fmt.Errorf("creating budget schedule failed")
+	}
+
+	return res.ID, nil
+}
+
+// UpdateBudgetSchedule updates a budget schedule.
+func (cs *CampaignService) UpdateBudgetSchedule(ctx context.Context, bs BudgetSchedule) error {
+	if bs.ID == "" {
+		return errors.New("cannot update budget schedule without id")
+	}
+	err := bs.validate()
+	if err != nil {
+		return err
+	}
+
+	return updateFields(ctx, cs.c, cs.v, bs.ID, map[string]interface{}{
+		"budget_value":      bs.BudgetValue,
+		"budget_value_type": bs.BudgetValueType,
+		"time_start":        bs.TimeStart,
+		"time_end":          bs.TimeEnd,
+	})
+}
+
+// DeleteBudgetSchedule removes a budget schedule.
+func (cs *CampaignService) DeleteBudgetSchedule(ctx context.Context, id string) error {
+	return cs.c.Delete(ctx, fb.NewRoute(cs.v.Name, "/%s", id).String())
+}
+
+// HasSpendCap returns true if the campaign has a spend cap.
+func (c Campaign) HasSpendCap() bool {
+	return c.SpendCap > 0 && c.SpendCap != NoSpendCap
+}
+
+// SetSpendCap sets the spend cap of a campaign in the smallest unit of the account currency.
+func (cs *CampaignService) SetSpendCap(ctx context.Context, campaignID string, spendCap uint64) error {
+	if spendCap == 0 {
+		return errors.New("spend cap must be positive, use RemoveSpendCap for removing it")
+	}
+
+	return updateFields(ctx, cs.c, cs.v, campaignID, map[string]interface{}{"spend_cap": spendCap})
+}
+
+// RemoveSpendCap removes the spend cap of a campaign.
+func (cs *CampaignService) RemoveSpendCap(ctx context.Context, campaignID string) error {
+	return updateFields(ctx, cs.c, cs.v, campaignID, map[string]interface{}{"spend_cap": uint64(NoSpendCap)})
+}
+
+// AdsetBudget is the budget of an adset, only one of the budgets is set.
+type AdsetBudget struct {
+	AdsetID        string `json:"adset_id"`
+	DailyBudget    uint64 `json:"daily_budget,omitempty"`
+	LifetimeBudget uint64 `json:"lifetime_budget,omitempty"`
+}
+
+// ConvertToAdsetBudgets switches a campaign from a campaign budget to adset budgets. The campaign budget
+// is split between the adsets of the campaign by the weights, e.g. the previous budgets returned by
+// ConvertToCampaignBudget, or evenly if there are no weights. The sum of the adset budgets equals the campaign budget.
+func (cs *CampaignService) ConvertToAdsetBudgets(ctx context.Context, campaignID string, weights map[string]float64) ([]AdsetBudget, error) {
+	c, err := cs.Get(ctx, campaignID, "id", "daily_budget", "lifetime_budget")
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		return nil, fmt.Errorf("did not find campaign %s", campaignID)
+	} else if c.DailyBudget == 0 && c.LifeTimeBudget == 0 {
+		return nil, fmt.Errorf("campaign %s has no campaign budget", campaignID)
+	}
+
+	adsets, err := cs.budgetAdsets(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(adsets))
+	for i, a := range adsets {
+		ids[i] = a.ID
+	}
+	total := c.DailyBudget
+	if total == 0 {
+		total = c.LifeTimeBudget
+	}
+	split, err := splitBudget(total, ids, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets := make([]AdsetBudget, len(ids))
+	for i, id := range ids {
+		budgets[i] = AdsetBudget{AdsetID: id, DailyBudget: split[id]}
+		if c.DailyBudget == 0 {
+			budgets[i] = AdsetBudget{AdsetID: id, LifetimeBudget: split[id]}
+		}
+	}
+	err = updateFields(ctx, cs.c, cs.v, campaignID, map[string]interface{}{"adset_budgets": budgets})
+	if err != nil {
+		return nil, err
+	}
+
+	return budgets, nil
+}
+
+// ConvertToCampaignBudget switches a campaign from adset budgets to a campaign budget of the sum of the adset budgets.
+// All adsets must either have a daily or a lifetime budget. The bid strategy defaults to LOWEST_COST_WITHOUT_CAP.
+// The replaced adset budgets are returned so they can be restored.
+func (cs *CampaignService) ConvertToCampaignBudget(ctx context.Context, campaignID, bidStrategy string) ([]AdsetBudget, error) {
+	c, err := cs.Get(ctx, campaignID, "id", "daily_budget", "lifetime_budget")
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		return nil, fmt.Errorf("did not find campaign %s", campaignID)
+	} else if c.DailyBudget > 0 || c.LifeTimeBudget > 0 {
+		return nil, fmt.Errorf("campaign %s already has a campaign budget", campaignID)
+	}
+
+	adsets, err := cs.budgetAdsets(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	budgets := make([]AdsetBudget, len(adsets))
+	var daily, lifetime uint64
+	for i, a := range adsets {
+		budgets[i] = AdsetBudget{AdsetID: a.ID, DailyBudget: uint64(a.DailyBudget), LifetimeBudget: uint64(a.LifetimeBudget)}
+		daily += budgets[i].DailyBudget
+		lifetime += budgets[i].LifetimeBudget
+		if (budgets[i].DailyBudget == 0) == (budgets[i].LifetimeBudget == 0) {
+			return nil, fmt.Errorf("adset %s needs either a daily or a lifetime budget", a.ID)
+		}
+	}
+	if daily > 0 && lifetime > 0 {
+		return nil, fmt.Errorf("adsets of campaign %s mix daily and lifetime budgets", campaignID)
+	}
+
+	if bidStrategy == "" {
+		bidStrategy = defaultCampaignBidStrategy
+	}
+	fields := map[string]interface{}{"daily_budget": daily, "bid_strategy": bidStrategy}
+	if lifetime > 0 {
+		fields = map[string]interface{}{"lifetime_budget": lifetime, "bid_strategy": bidStrategy}
+	}
+	err = updateFields(ctx, cs.c, cs.v, campaignID, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return budgets, nil
+}
+
+// budgetAdsets returns the adsets of a campaign that are not deleted or archived.
+func (cs *CampaignService) budgetAdsets(ctx context.Context, campaignID string) ([]Adset, error) {
+	all, err := (&AdsetService{cs.c, cs.v}).ListOfCampaign(campaignID, []string{"id", "status", "daily_budget", "lifetime_budget"}).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	adsets := []Adset{}
+	for _, a := range all {
+		if a.Status != StatusDeleted && a.Status != StatusArchived {
+			adsets = append(adsets, a)
+		}
+	}
+	if len(adsets) == 0 {
+		return nil, fmt.Errorf("campaign %s has no adsets", campaignID)
+	}
+	sort.Slice(adsets, func(i, j int) bool {
+		return adsets[i].ID < adsets[j].ID
+	})
+
+	return adsets, nil
+}
+
+// splitBudget splits the total by the weights of the ids, evenly if there are no weights.
+// The remainder of rounding down is distributed by the largest fractions, so the budgets add up to total.
+func splitBudget(total uint64, ids []string, weights map[string]float64) (map[string]uint64, error) {
+	sum := 0.0
+	for _, id := range ids {
+		w, ok := weights[id]
+		if len(weights) > 0 && (!ok || w <= 0) {
+			return nil, fmt.Errorf("missing positive weight of %s", id)
+		} else if len(weights) == 0 {
+			w = 1
+		}
+		sum += w
+	}
+
+	res := map[string]uint64{}
+	fractions := make([]float64, len(ids))
+	var assigned uint64
+	for i, id := range ids {
+		w := 1.0
+		if len(weights) > 0 {
+			w = weights[id]
+		}
+		share := float64(total) * w / sum
+		res[id] = uint64(math.Floor(share))
+		fractions[i] = share - math.Floor(share)
+		assigned += res[id]
+	}
+
+	order := make([]int, len(ids))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return fractions[order[a]] > fractions[order[b]]
+	})
+	for i := 0; assigned < total; i++ {
+		res[ids[order[i%len(order)]]]++
+		assigned++
+	}
+
+	return res, nil
+}
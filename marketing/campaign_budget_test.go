@@ -0,0 +1,96 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestSplitBudget(t *testing.T) {
+	got, err := splitBudget(1000, []string{"a", "b", "c"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]uint64{"a": 334, "b": 333, "c": 333}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("split = %v, want %v", got, want)
+	}
+
+	got, err = splitBudget(1000, []string{"a", "b"}, map[string]float64{"a": 1, "b": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]uint64{"a": 250, "b": 750}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("split = %v, want %v", got, want)
+	}
+
+	if _, err := splitBudget(1000, []string{"a", "b"}, map[string]float64{"a": 1}); err == nil {
+		t.Fatal("expected error for missing weight")
+	}
+}
+
+func TestConvertBudgets(t *testing.T) {
+	campaign := `{"id":"c1","daily_budget":"3000"}`
+	posted := map[string]interface{}{}
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: campaignBudgetRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		body := ""
+		switch {
+		case request.Method == http.MethodPost && request.URL.Path == "/v24.0/c1":
+			if err := json.NewDecoder(request.Body).Decode(&posted); err != nil {
+				t.Fatal(err)
+			}
+			body = `{"success":true}`
+		case request.URL.Path == "/v24.0/c1":
+			body = campaign
+		case request.URL.Path == "/v24.0/c1/adsets":
+			body = `{"data":[{"id":"a2","status":"ACTIVE","daily_budget":"500"},{"id":"a1","status":"PAUSED","daily_budget":"1000"},{"id":"a3","status":"ARCHIVED"}]}`
+		default:
+			t.Fatalf("unexpected request %s %s", request.Method, request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &CampaignService{c: client, v: Version{Name: "v24.0"}}
+	ctx := context.Background()
+
+	budgets, err := service.ConvertToAdsetBudgets(ctx, "c1", map[string]float64{"a1": 2, "a2": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []AdsetBudget{{AdsetID: "a1", DailyBudget: 2000}, {AdsetID: "a2", DailyBudget: 1000}}; !reflect.DeepEqual(budgets, want) {
+		t.Fatalf("budgets = %+v", budgets)
+	}
+	if b, _ := json.Marshal(posted); string(b) != `{"adset_budgets":[{"adset_id":"a1","daily_budget":2000},{"adset_id":"a2","daily_budget":1000}]}` {
+		t.Fatalf("posted %s", b)
+	}
+
+	if _, err := service.ConvertToCampaignBudget(ctx, "c1", ""); err == nil {
+		t.Fatal("expected error for campaign with campaign budget")
+	}
+	campaign = `{"id":"c1"}`
+	budgets, err = service.ConvertToCampaignBudget(ctx, "c1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(budgets) != 2 || posted["daily_budget"] != float64(1500) || posted["bid_strategy"] != "LOWEST_COST_WITHOUT_CAP" {
+		t.Fatalf("posted %v for %+v", posted, budgets)
+	}
+}
+
+type campaignBudgetRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f campaignBudgetRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
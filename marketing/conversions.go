@@ -0,0 +1,159 @@
+package marketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+// MaxEventsPerRequest is the maximum number of events the Conversions API accepts in one request.
+const MaxEventsPerRequest = 1000
+
+// ConversionsService sends server events to the Conversions API.
+type ConversionsService struct {
+	c *fb.Client
+	v Version
+}
+
+// EventsOptions are sent along with every chunk of events.
+type EventsOptions struct {
+	// TestEventCode routes the events to the Test Events tool of the events manager.
+	TestEventCode string `json:"test_event_code,omitempty"`
+	// PartnerAgent identifies the platform sending the events.
+	PartnerAgent string `json:"partner_agent,omitempty"`
+	// NamespaceID scopes external_id values for offline events.
+	NamespaceID string `json:"namespace_id,omitempty"`
+}
+
+type eventsRequest struct {
+	Data types.ServerEvents `json:"data"`
+	EventsOptions
+}
+
+// EventsResponse is the response to a single chunk of events.
+type EventsResponse struct {
+	EventsReceived uint64   `json:"events_received"`
+	Messages       []string `json:"messages"`
+	FbtraceID      string   `json:"fbtrace_id"`
+	// Indices are the positions of the chunk's events in the slice passed to Send.
+	Indices []int `json:"-"`
+	// Err is set if the chunk failed, the other fields are empty then.
+	Err error `json:"-"`
+}
+
+// Send validates the events and posts them to the pixel in chunks of MaxEventsPerRequest.
+// Invalid events are not sent. One response is returned for every chunk, including the failed
+// ones, so the responses can be matched to the events by their Indices. If events were invalid
+// or chunks failed, a *SendError is returned as well.
+func (cs *ConversionsService) Send(ctx context.Context, pixelID string, events types.ServerEvents, opts EventsOptions) ([]EventsResponse, error) {
+	if pixelID == "" {
+		return nil, errors.New("cannot send events without pixel id")
+	}
+
+	sendErr := &SendError{}
+	now := time.Now()
+	valid := make(types.ServerEvents, 0, len(events))
+	indices := make([]int, 0, len(events))
+	for i, e := range events {
+		err := e.Validate(now)
+		if err != nil {
+			sendErr.Events = append(sendErr.Events, EventError{Index: i, EventID: e.EventID, Err: err})
+
+			continue
+		}
+		valid = append(valid, e)
+		indices = append(indices, i)
+	}
+
+	res := []EventsResponse{}
+	for start := 0; start < len(valid); start += MaxEventsPerRequest {
+		end := start + MaxEventsPerRequest
+		if end > len(valid) {
+			end = len(valid)
+		}
+
+		r, err := cs.send(ctx, pixelID, valid[start:end], opts)
+		if err != nil {
+			sendErr.Chunks = append(sendErr.Chunks, ChunkError{Indices: indices[start:end], Err: err})
+			res = append(res, EventsResponse{Indices: indices[start:end], Err: err})
+
+			continue
+		}
+		r.Indices = indices[start:end]
+		res = append(res, *r)
+	}
+
+	if len(sendErr.Events) > 0 || len(sendErr.Chunks) > 0 {
+		return res, sendErr
+	}
+
+	return res, nil
+}
+
+func (cs *ConversionsService) send(ctx context.Context, pixelID string, events types.ServerEvents, opts EventsOptions) (*EventsResponse, error) {
+	res := &struct {
+		EventsResponse
+		fb.ErrorContainer
+	}{}
+	err := cs.c.PostJSON(ctx, fb.NewRoute(cs.v.Name, "/%s/events", pixelID).String(), eventsRequest{
+		Data:          events,
+		EventsOptions: opts,
+	}, res)
+	if err != nil {
+		return nil, err
+	} else if err = res.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &res.EventsResponse, nil
+}
+
+// EventError is a validation error of a single event.
+type EventError struct {
+	// Index is the position of the event in the slice passed to Send.
+	Index   int
+	EventID string
+	Err     error
+}
+
+func (ee EventError) Error() string {
+	return fmt.Sprintf("event %d (event_id '%s'): %s", ee.Index, ee.EventID, ee.Err)
+}
+
+// ChunkError is an error returned by the graph API for a chunk of events.
+type ChunkError struct {
+	// Indices are the positions of the chunk's events in the slice passed to Send.
+	Indices []int
+	Err     error
+}
+
+func (ce ChunkError) Error() string {
+	if len(ce.Indices) == 0 {
+		return ce.Err.Error()
+	}
+
+	return fmt.Sprintf("events %d to %d: %s", ce.Indices[0], ce.Indices[len(ce.Indices)-1], ce.Err)
+}
+
+// SendError contains the events that were not accepted by ConversionsService.Send.
+type SendError struct {
+	Events []EventError
+	Chunks []ChunkError
+}
+
+func (se *SendError) Error() string {
+	msgs := make([]string, 0, len(se.Events)+len(se.Chunks))
+	for _, e := range se.Events {
+		msgs = append(msgs, e.Error())
+	}
+	for _, c := range se.Chunks {
+		msgs = append(msgs, c.Error())
+	}
+
+	return fmt.Sprintf("%d invalid events, %d failed chunks: %s", len(se.Events), len(se.Chunks), strings.Join(msgs, "; "))
+}
@@ -0,0 +1,317 @@
+package marketing
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+// Keys of CSVMapping.Columns and CSVMapping.Defaults, they are named after the API parameters.
+const (
+	CSVEventName    = "event_name"
+	CSVEventID      = "event_id"
+	CSVEventTime    = "event_time"
+	CSVActionSource = "action_source"
+	CSVValue        = "value"
+	CSVCurrency     = "currency"
+	CSVOrderID      = "order_id"
+	CSVContentIDs   = "content_ids"
+	CSVEmail        = "em"
+	CSVPhone        = "ph"
+	CSVFirstName    = "fn"
+	CSVLastName     = "ln"
+	CSVCity         = "ct"
+	CSVState        = "st"
+	CSVZip          = "zp"
+	CSVCountry      = "country"
+	CSVDateOfBirth  = "db"
+	CSVGender       = "ge"
+	CSVExternalID   = "external_id"
+	CSVLeadID       = "lead_id"
+)
+
+var csvKeys = map[string]bool{
+	CSVEventName: true, CSVEventID: true, CSVEventTime: true, CSVActionSource: true, CSVValue: true,
+	CSVCurrency: true, CSVOrderID: true, CSVContentIDs: true, CSVEmail: true, CSVPhone: true,
+	CSVFirstName: true, CSVLastName: true, CSVCity: true, CSVState: true, CSVZip: true,
+	CSVCountry: true, CSVDateOfBirth: true, CSVGender: true, CSVExternalID: true, CSVLeadID: true,
+}
+
+// CSVMapping maps the columns of a CSV file with a header row to server events.
+// It can be decoded from JSON, e.g. for command-line tools.
+type CSVMapping struct {
+	// Columns maps parameter keys (e.g. CSVEmail) to column names of the header row.
+	Columns map[string]string `json:"columns"`
+	// Defaults are used for parameters without column or with an empty cell,
+	// e.g. {"event_name": "Purchase", "currency": "EUR"}.
+	Defaults map[string]string `json:"defaults,omitempty"`
+	// TimeLayout is the time.Parse layout of event_time, "unix" for unix seconds. Defaults to time.RFC3339.
+	TimeLayout string `json:"time_layout,omitempty"`
+	// Comma is the field delimiter, defaults to ','.
+	Comma string `json:"comma,omitempty"`
+	// ListSeparator separates the values of content_ids and the PII columns, defaults to '|'.
+	ListSeparator string `json:"list_separator,omitempty"`
+}
+
+// Validate checks that all keys are known and that the event time is mapped.
+func (m CSVMapping) Validate() error {
+	for _, keys := range []map[string]string{m.Columns, m.Defaults} {
+		for k := range keys {
+			if !csvKeys[k] {
+				return fmt.Errorf("unknown parameter '%s'", k)
+			}
+		}
+	}
+	if m.Columns[CSVEventTime] == "" {
+		return errors.New("missing event_time column")
+	} else if m.Columns[CSVEventName] == "" && m.Defaults[CSVEventName] == "" {
+		return errors.New("missing event_name column or default")
+	} else if len([]rune(m.Comma)) > 1 {
+		return fmt.Errorf("invalid comma '%s'", m.Comma)
+	}
+
+	return nil
+}
+
+// RowError is the error of a single CSV row, Row is the line in the file the row starts on.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (re RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", re.Row, re.Err)
+}
+
+// ParsedRow is a CSV row that was mapped to a valid server event.
+type ParsedRow struct {
+	Row   int
+	Event types.ServerEvent
+}
+
+// Parse maps all rows of r to server events, normalizing and hashing PII.
+// Invalid rows are reported as RowErrors, an error is only returned if r cannot be read.
+func (m CSVMapping) Parse(r io.Reader) ([]ParsedRow, []RowError, error) {
+	err := m.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cr := csv.NewReader(r)
+	if m.Comma != "" {
+		cr.Comma = []rune(m.Comma)[0]
+	}
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read header: %w", err)
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+	cols := map[string]int{}
+	for k, name := range m.Columns {
+		i, ok := index[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("column '%s' of %s not found in header", name, k)
+		}
+		cols[k] = i
+	}
+
+	var rows []ParsedRow
+	var rowErrs []RowError
+	now := time.Now()
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		parseErr := &csv.ParseError{}
+		if errors.As(err, &parseErr) {
+			rowErrs = append(rowErrs, RowError{Row: parseErr.StartLine, Err: err})
+
+			continue
+		} else if err != nil {
+			return rows, rowErrs, err
+		}
+		// quoted fields can span lines, so the row is the line of the first field
+		line, _ := cr.FieldPos(0)
+
+		get := func(k string) string {
+			if i, ok := cols[k]; ok && i < len(record) {
+				if v := strings.TrimSpace(record[i]); v != "" {
+					return v
+				}
+			}
+
+			return m.Defaults[k]
+		}
+		e, err := m.event(get)
+		if err == nil {
+			err = e.Validate(now)
+		}
+		if err != nil {
+			rowErrs = append(rowErrs, RowError{Row: line, Err: err})
+
+			continue
+		}
+		rows = append(rows, ParsedRow{Row: line, Event: e})
+	}
+
+	return rows, rowErrs, nil
+}
+
+func (m CSVMapping) event(get func(string) string) (types.ServerEvent, error) {
+	t, err := m.parseTime(get(CSVEventTime))
+	if err != nil {
+		return types.ServerEvent{}, err
+	}
+	actionSource := types.ActionSource(get(CSVActionSource))
+	if actionSource == "" {
+		actionSource = types.PhysicalStore
+	}
+
+	e := types.NewServerEvent(get(CSVEventName), get(CSVEventID), types.UnixTime(t.Unix()), actionSource)
+	cd := types.NewCustomData()
+	if v := get(CSVValue); v != "" {
+		value, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return e, fmt.Errorf("invalid value '%s'", v)
+		}
+		cd = cd.WithValue(value, get(CSVCurrency))
+	} else {
+		cd.Currency = strings.ToUpper(get(CSVCurrency))
+	}
+	cd.OrderID = get(CSVOrderID)
+	if ids := m.list(get(CSVContentIDs)); len(ids) > 0 {
+		cd = cd.WithContentIDs(types.ContentTypeProduct, ids...)
+	}
+	if cd.Value != nil || cd.OrderID != "" || len(cd.ContentIDs) > 0 {
+		e = e.WithCustomData(cd)
+	}
+
+	ci := types.NewCustomerInformation().
+		WithEmail(m.list(get(CSVEmail))...).
+		WithPhoneNumber(m.list(get(CSVPhone))...).
+		WithFirstName(m.list(get(CSVFirstName))...).
+		WithLastName(m.list(get(CSVLastName))...).
+		WithCity(m.list(get(CSVCity))...).
+		WithState(m.list(get(CSVState))...).
+		WithZip(m.list(get(CSVZip))...).
+		WithCountry(m.list(get(CSVCountry))...).
+		WithDateOfBirth(m.list(get(CSVDateOfBirth))...).
+		WithGender(m.list(get(CSVGender))...).
+		WithExternalID(m.list(get(CSVExternalID))...)
+	if v := get(CSVLeadID); v != "" {
+		leadID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return e, fmt.Errorf("invalid lead_id '%s'", v)
+		}
+		ci = ci.WithLeadID(leadID)
+	}
+	if len(ci.Email) == 0 && len(ci.PhoneNumber) == 0 && len(ci.ExternalID) == 0 && ci.LeadID == 0 &&
+		(len(ci.FirstName) == 0 || len(ci.LastName) == 0) {
+		return e, errors.New("no customer information to match, need em, ph, external_id, lead_id or fn and ln")
+	}
+
+	return e.WithUserData(ci), nil
+}
+
+func (m CSVMapping) parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("missing event_time")
+	}
+	if m.TimeLayout == "unix" {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid event_time '%s'", s)
+		}
+
+		return time.Unix(sec, 0), nil
+	}
+
+	layout := m.TimeLayout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid event_time '%s'", s)
+	}
+
+	return t, nil
+}
+
+func (m CSVMapping) list(s string) []string {
+	if s == "" {
+		return nil
+	}
+	sep := m.ListSeparator
+	if sep == "" {
+		sep = "|"
+	}
+
+	return strings.Split(s, sep)
+}
+
+// ImportReport is the result of ConversionsService.ImportCSV.
+type ImportReport struct {
+	// Rows is the number of data rows, Sent the number of events accepted by the API.
+	Rows      int
+	Sent      int
+	Errors    []RowError
+	Responses []EventsResponse
+}
+
+// ImportCSV parses the rows of r with the mapping and sends the valid events to the pixel in
+// batches of MaxEventsPerRequest. Rows that are invalid or part of a failed batch are reported
+// in ImportReport.Errors, the returned error is only set if r cannot be read.
+func (cs *ConversionsService) ImportCSV(ctx context.Context, pixelID string, r io.Reader, m CSVMapping, opts EventsOptions) (*ImportReport, error) {
+	rows, rowErrs, err := m.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{Rows: len(rows) + len(rowErrs), Errors: rowErrs}
+	if len(rows) == 0 {
+		return report, nil
+	}
+
+	events := make(types.ServerEvents, len(rows))
+	for i, row := range rows {
+		events[i] = row.Event
+	}
+	report.Responses, err = cs.Send(ctx, pixelID, events, opts)
+	report.Sent = len(rows)
+
+	sendErr := &SendError{}
+	if errors.As(err, &sendErr) {
+		for _, ee := range sendErr.Events {
+			report.Errors = append(report.Errors, RowError{Row: rows[ee.Index].Row, Err: ee.Err})
+			report.Sent--
+		}
+		for _, ce := range sendErr.Chunks {
+			for _, i := range ce.Indices {
+				report.Errors = append(report.Errors, RowError{Row: rows[i].Row, Err: ce.Err})
+			}
+			report.Sent -= len(ce.Indices)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	sort.Slice(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Row < report.Errors[j].Row
+	})
+
+	return report, nil
+}
@@ -0,0 +1,133 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+func TestImportCSV(t *testing.T) {
+	day := time.Now().Add(-24 * time.Hour).UTC().Format("2006-01-02")
+	csvData := strings.Join([]string{
+		"Receipt;Date;Total;E-Mail;Phone;Items",
+		"r-1;" + day + ";19.99;John@Example.com ;+1 650 555 1212;sku-1|sku-2",
+		"r-2;" + day + ";;jane@example.com;;\"sku-4|",
+		"sku-5\"",
+		"r-3;yesterday;5;jim@example.com;;",
+		"r-4;" + day + ";7.50;;;",
+		"r-5;" + day + ";3;jo@example.com;;sku-3",
+	}, "\n")
+
+	var sent types.ServerEvents
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: conversionsRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		req := eventsRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode events request: %v", err)
+		}
+		sent = append(sent, req.Data...)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"events_received":%d}`, len(req.Data)))),
+			Request:    request,
+		}, nil
+	})}
+	service := &ConversionsService{c: client, v: Version{Name: "v24.0"}}
+
+	m := CSVMapping{
+		Columns: map[string]string{
+			CSVEventID: "Receipt", CSVOrderID: "Receipt", CSVEventTime: "Date", CSVValue: "Total",
+			CSVEmail: "E-Mail", CSVPhone: "Phone", CSVContentIDs: "Items",
+		},
+		Defaults:   map[string]string{CSVEventName: "Purchase", CSVCurrency: "eur"},
+		TimeLayout: "2006-01-02",
+		Comma:      ";",
+	}
+	report, err := service.ImportCSV(context.Background(), "pixel-1", strings.NewReader(csvData), m, EventsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Rows != 5 || report.Sent != 2 || len(sent) != 2 {
+		t.Fatalf("unexpected report %+v with %d sent events", report, len(sent))
+	}
+	var rows []int
+	for _, re := range report.Errors {
+		rows = append(rows, re.Row)
+	}
+	// the lines the rows start on, r-2 spans lines 3 and 4
+	if fmt.Sprint(rows) != "[3 5 6]" {
+		t.Fatalf("error rows = %v, want [3 5 6]: %v", rows, report.Errors)
+	}
+
+	e := sent[0]
+	if e.ActionSource != types.PhysicalStore || e.EventID != "r-1" || e.CustomData.OrderID != "r-1" ||
+		*e.CustomData.Value != 19.99 || e.CustomData.Currency != "EUR" || len(e.CustomData.ContentIDs) != 2 {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	if e.UserData.Email[0] != types.Hash(types.NormalizeEmail, "john@example.com") || e.UserData.PhoneNumber[0] != types.Hash(types.NormalizePhone, "16505551212") {
+		t.Fatalf("unexpected user data: %+v", e.UserData)
+	}
+}
+
+func TestImportCSVOldStoreSales(t *testing.T) {
+	day := time.Now().Add(-30 * 24 * time.Hour).UTC().Format("2006-01-02")
+	csvData := strings.Join([]string{
+		"Receipt,Date,Total,Source,Email",
+		"r-1," + day + ",10,physical_store,john@example.com",
+		"r-2," + day + ",10,website,jane@example.com",
+	}, "\n")
+
+	var sent types.ServerEvents
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: conversionsRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		req := eventsRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode events request: %v", err)
+		}
+		sent = append(sent, req.Data...)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"events_received":%d}`, len(req.Data)))),
+			Request:    request,
+		}, nil
+	})}
+	service := &ConversionsService{c: client, v: Version{Name: "v24.0"}}
+
+	m := CSVMapping{
+		Columns:    map[string]string{CSVEventID: "Receipt", CSVEventTime: "Date", CSVValue: "Total", CSVActionSource: "Source", CSVEmail: "Email"},
+		Defaults:   map[string]string{CSVEventName: "Purchase", CSVCurrency: "EUR"},
+		TimeLayout: "2006-01-02",
+	}
+	report, err := service.ImportCSV(context.Background(), "pixel-1", strings.NewReader(csvData), m, EventsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Sent != 1 || len(sent) != 1 || sent[0].EventID != "r-1" {
+		t.Fatalf("unexpected report %+v with sent events %+v", report, sent)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Row != 3 {
+		t.Fatalf("unexpected errors %v", report.Errors)
+	}
+}
+
+func TestCSVMappingValidate(t *testing.T) {
+	err := CSVMapping{Columns: map[string]string{CSVEventTime: "Date", "email": "E-Mail"}}.Validate()
+	if err == nil || !strings.Contains(err.Error(), "unknown parameter 'email'") {
+		t.Fatalf("err = %v, want unknown parameter", err)
+	}
+}
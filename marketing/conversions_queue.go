@@ -0,0 +1,208 @@
+package marketing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+// QueuedEvent is a server event waiting in an EventQueue.
+type QueuedEvent struct {
+	Event    types.ServerEvent `json:"event"`
+	Enqueued time.Time         `json:"enqueued"`
+	Attempts int               `json:"attempts,omitempty"`
+	// NotBefore delays the next attempt of a failed event.
+	NotBefore time.Time `json:"not_before"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+// EventQueue is a FIFO queue of server events used by EventSpool.
+// Implementations must be safe for concurrent use.
+type EventQueue interface {
+	// Append adds events to the tail of the queue.
+	Append(events ...QueuedEvent) error
+	// Peek returns up to n events from the head of the queue without removing them.
+	Peek(n int) ([]QueuedEvent, error)
+	// Remove removes n events from the head of the queue.
+	Remove(n int) error
+	// Len returns the number of queued events.
+	Len() int
+}
+
+// MemoryEventQueue is an EventQueue that keeps the events in memory,
+// they are lost when the process exits.
+type MemoryEventQueue struct {
+	mu     sync.Mutex
+	events []QueuedEvent
+}
+
+// NewMemoryEventQueue returns an empty MemoryEventQueue.
+func NewMemoryEventQueue() *MemoryEventQueue {
+	return &MemoryEventQueue{}
+}
+
+// Append implements EventQueue.
+func (q *MemoryEventQueue) Append(events ...QueuedEvent) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.events = append(q.events, events...)
+
+	return nil
+}
+
+// Peek implements EventQueue.
+func (q *MemoryEventQueue) Peek(n int) ([]QueuedEvent, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n > len(q.events) {
+		n = len(q.events)
+	}
+
+	return append([]QueuedEvent(nil), q.events[:n]...), nil
+}
+
+// Remove implements EventQueue.
+func (q *MemoryEventQueue) Remove(n int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n > len(q.events) {
+		return fmt.Errorf("cannot remove %d of %d events", n, len(q.events))
+	}
+	q.events = append([]QueuedEvent(nil), q.events[n:]...)
+
+	return nil
+}
+
+// Len implements EventQueue.
+func (q *MemoryEventQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.events)
+}
+
+// FileEventQueue is an EventQueue persisted as JSON lines in a local file.
+// Appends are synced to disk, removals atomically rewrite the file.
+type FileEventQueue struct {
+	MemoryEventQueue
+	path string
+}
+
+// NewFileEventQueue opens the queue stored at path, the file is created if it does not exist.
+// A truncated last line, as left by a crash during Append, is dropped.
+func NewFileEventQueue(path string) (*FileEventQueue, error) {
+	q := &FileEventQueue{path: path}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, q.write()
+	} else if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(b), []byte("\n"))
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		e := QueuedEvent{}
+		err = json.Unmarshal(line, &e)
+		if err != nil && i == len(lines)-1 {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("line %d of %s: %w", i+1, path, err)
+		}
+		q.events = append(q.events, e)
+	}
+
+	return q, q.write()
+}
+
+// Append implements EventQueue.
+func (q *FileEventQueue) Append(events ...QueuedEvent) error {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	for _, e := range events {
+		err := enc.Encode(e)
+		if err != nil {
+			return err
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	f, err := os.OpenFile(q.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(buf.Bytes())
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	q.events = append(q.events, events...)
+
+	return nil
+}
+
+// Remove implements EventQueue.
+func (q *FileEventQueue) Remove(n int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n > len(q.events) {
+		return fmt.Errorf("cannot remove %d of %d events", n, len(q.events))
+	}
+	events := q.events
+	q.events = append([]QueuedEvent(nil), q.events[n:]...)
+	err := q.write()
+	if err != nil {
+		q.events = events
+	}
+
+	return err
+}
+
+// write atomically replaces the file with the queued events, q.mu must be held.
+func (q *FileEventQueue) write() error {
+	tmp := q.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, e := range q.events {
+		err = enc.Encode(e)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+
+		return err
+	}
+
+	return os.Rename(tmp, q.path)
+}
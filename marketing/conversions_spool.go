@@ -0,0 +1,341 @@
+package marketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+// SpoolConfig configures an EventSpool, zero values are replaced by defaults.
+type SpoolConfig struct {
+	// BatchSize is the number of queued events that triggers a flush, at most MaxEventsPerRequest.
+	BatchSize int
+	// MaxDelay is how long an event may wait in the queue before it is flushed, at least minSpoolDelay.
+	MaxDelay time.Duration
+	// MaxAttempts is the number of failed attempts after which an event is dead-lettered.
+	MaxAttempts int
+	// RetryInterval is the delay before the first retry, it doubles with every attempt.
+	RetryInterval time.Duration
+}
+
+// minSpoolDelay is the smallest MaxDelay, the queue is checked twice per MaxDelay.
+const minSpoolDelay = 100 * time.Millisecond
+
+func (cfg SpoolConfig) withDefaults() SpoolConfig {
+	if cfg.BatchSize <= 0 || cfg.BatchSize > MaxEventsPerRequest {
+		cfg.BatchSize = MaxEventsPerRequest
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = 10 * time.Second
+	} else if cfg.MaxDelay < minSpoolDelay {
+		cfg.MaxDelay = minSpoolDelay
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = time.Minute
+	}
+
+	return cfg
+}
+
+// EventSpool buffers server events in an EventQueue and sends them to the Conversions API in batches,
+// so that events are not lost while the graph API is unavailable. Each request is retried by the
+// client's retry transport, batches that still fail are retried later. Delivery is at-least-once,
+// set event ids to let Meta deduplicate events. Events that cannot be delivered within MaxAttempts
+// or the event_time window of their action source are moved to the dead-letter queue.
+type EventSpool struct {
+	l           log.Logger
+	cs          *ConversionsService
+	pixelID     string
+	opts        EventsOptions
+	queue       EventQueue
+	deadLetters EventQueue
+	cfg         SpoolConfig
+	flush       chan struct{}
+	flushMu     sync.Mutex
+
+	mu   sync.Mutex
+	keys map[string]struct{}
+	now  func() time.Time
+}
+
+// NewSpool returns an EventSpool sending the events in queue to the pixel.
+// Events already in the queue, e.g. from a FileEventQueue, are picked up.
+func (cs *ConversionsService) NewSpool(l log.Logger, pixelID string, opts EventsOptions, queue, deadLetters EventQueue, cfg SpoolConfig) (*EventSpool, error) {
+	if pixelID == "" {
+		return nil, errors.New("cannot spool events without pixel id")
+	} else if queue == nil || deadLetters == nil {
+		return nil, errors.New("cannot spool events without queue and dead-letter queue")
+	}
+
+	s := &EventSpool{
+		l:           l,
+		cs:          cs,
+		pixelID:     pixelID,
+		opts:        opts,
+		queue:       queue,
+		deadLetters: deadLetters,
+		cfg:         cfg.withDefaults(),
+		flush:       make(chan struct{}, 1),
+		keys:        map[string]struct{}{},
+		now:         time.Now,
+	}
+
+	queued, err := queue.Peek(queue.Len())
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range queued {
+		if k := dedupKey(e.Event); k != "" {
+			s.keys[k] = struct{}{}
+		}
+	}
+
+	return s, nil
+}
+
+// dedupKey returns the key Meta uses to deduplicate events, or "" for events without event id.
+func dedupKey(e types.ServerEvent) string {
+	if e.EventID == "" {
+		return ""
+	}
+
+	return e.EventName + "\x00" + e.EventID
+}
+
+// Send appends the events to the queue. Events with the same event name and id as an
+// event still in the queue are dropped. A full batch triggers a flush in Run.
+func (s *EventSpool) Send(events ...types.ServerEvent) error {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queued := make([]QueuedEvent, 0, len(events))
+	added := map[string]struct{}{}
+	for _, e := range events {
+		k := dedupKey(e)
+		if k != "" {
+			if _, ok := s.keys[k]; ok {
+				continue
+			} else if _, ok := added[k]; ok {
+				continue
+			}
+			added[k] = struct{}{}
+		}
+		queued = append(queued, QueuedEvent{Event: e, Enqueued: now})
+	}
+
+	err := s.queue.Append(queued...)
+	if err != nil {
+		return err
+	}
+	for k := range added {
+		s.keys[k] = struct{}{}
+	}
+
+	if s.queue.Len() >= s.cfg.BatchSize {
+		select {
+		case s.flush <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Run flushes the queue whenever a batch is full or the oldest event waited MaxDelay, until ctx is done.
+// Events still queued when Run returns stay in the queue.
+func (s *EventSpool) Run(ctx context.Context) error {
+	t := time.NewTicker(s.cfg.MaxDelay / 2)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.flush:
+		case <-t.C:
+			if !s.due() {
+				continue
+			}
+		}
+
+		err := s.Flush(ctx)
+		if err != nil {
+			_ = level.Warn(s.l).Log("msg", "flushing conversions spool failed", "pixel_id", s.pixelID, "err", err)
+		}
+	}
+}
+
+// due returns whether an event waited MaxDelay or is due for a retry.
+func (s *EventSpool) due() bool {
+	queued, err := s.queue.Peek(s.queue.Len())
+	if err != nil {
+		return false
+	}
+
+	now := s.now()
+	for _, e := range queued {
+		if e.Attempts == 0 && now.Sub(e.Enqueued) >= s.cfg.MaxDelay {
+			return true
+		} else if e.Attempts > 0 && !now.Before(e.NotBefore) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Flush sends all queued events that are not waiting for a retry.
+func (s *EventSpool) Flush(ctx context.Context) error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	for remaining := s.queue.Len(); remaining > 0; {
+		n := s.cfg.BatchSize
+		if n > remaining {
+			n = remaining
+		}
+		batch, err := s.queue.Peek(n)
+		if err != nil {
+			return err
+		} else if len(batch) == 0 {
+			return nil
+		}
+		remaining -= len(batch)
+
+		err = s.flushBatch(ctx, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flushBatch sends the due events of the batch at the head of the queue. Failed and deferred events
+// are appended to the tail and dead letters are stored before the batch is removed, so no event is lost.
+func (s *EventSpool) flushBatch(ctx context.Context, batch []QueuedEvent) error {
+	now := s.now()
+	var send []QueuedEvent
+	var retry, dead []QueuedEvent
+	for _, e := range batch {
+		switch {
+		case now.Sub(e.Event.EventTime.Time()) > e.Event.MaxAge():
+			e.LastError = fmt.Sprintf("event_time is older than the %s window", e.Event.MaxAge())
+			dead = append(dead, e)
+		case now.Before(e.NotBefore):
+			retry = append(retry, e)
+		default:
+			send = append(send, e)
+		}
+	}
+
+	var delivered []QueuedEvent
+	if len(send) > 0 {
+		events := make(types.ServerEvents, len(send))
+		for i, e := range send {
+			events[i] = e.Event
+		}
+
+		_, err := s.cs.Send(ctx, s.pixelID, events, s.opts)
+		if ctx.Err() != nil {
+			// keep the batch queued without counting an attempt
+			return ctx.Err()
+		}
+		failed := map[int]error{}
+		permanent := map[int]bool{}
+		sendErr := &SendError{}
+		if errors.As(err, &sendErr) {
+			for _, ee := range sendErr.Events {
+				failed[ee.Index] = ee.Err
+				permanent[ee.Index] = true
+			}
+			for _, ce := range sendErr.Chunks {
+				for _, i := range ce.Indices {
+					failed[i] = ce.Err
+					permanent[i] = isPermanent(ce.Err)
+				}
+			}
+		} else if err != nil {
+			return err
+		}
+
+		for i, e := range send {
+			err, ok := failed[i]
+			if !ok {
+				delivered = append(delivered, e)
+
+				continue
+			}
+
+			e.Attempts++
+			e.LastError = err.Error()
+			if permanent[i] || e.Attempts >= s.cfg.MaxAttempts {
+				dead = append(dead, e)
+
+				continue
+			}
+			e.NotBefore = now.Add(s.retryDelay(e.Attempts))
+			retry = append(retry, e)
+		}
+	}
+
+	if len(dead) > 0 {
+		err := s.deadLetters.Append(dead...)
+		if err != nil {
+			return err
+		}
+		_ = level.Warn(s.l).Log("msg", "dead-lettered conversions events", "pixel_id", s.pixelID, "count", len(dead), "err", dead[0].LastError)
+	}
+	if len(retry) > 0 {
+		err := s.queue.Append(retry...)
+		if err != nil {
+			return err
+		}
+	}
+	err := s.queue.Remove(len(batch))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range append(delivered, dead...) {
+		delete(s.keys, dedupKey(e.Event))
+	}
+
+	return nil
+}
+
+// retryDelay doubles RetryInterval with every attempt, capped at MaxEventAge.
+func (s *EventSpool) retryDelay(attempts int) time.Duration {
+	d := s.cfg.RetryInterval
+	for i := 1; i < attempts && d < types.MaxEventAge; i++ {
+		d *= 2
+	}
+	if d > types.MaxEventAge {
+		d = types.MaxEventAge
+	}
+
+	return d
+}
+
+// isPermanent returns whether a graph API error will not go away by retrying.
+func isPermanent(err error) bool {
+	fbErr := &fb.Error{}
+	if !errors.As(err, &fbErr) {
+		return false
+	}
+
+	return !fb.IsRateLimited(fbErr) && !fbErr.IsTransient && fbErr.Code != 1 && fbErr.Code != 2
+}
@@ -0,0 +1,191 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+func newTestSpool(t *testing.T, handle func(req eventsRequest) (int, string)) (*EventSpool, *MemoryEventQueue, *MemoryEventQueue) {
+	t.Helper()
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: conversionsRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		req := eventsRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode events request: %v", err)
+		}
+		code, body := handle(req)
+
+		return &http.Response{
+			StatusCode: code,
+			Status:     http.StatusText(code),
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+
+	queue, deadLetters := NewMemoryEventQueue(), NewMemoryEventQueue()
+	cs := &ConversionsService{c: client, v: Version{Name: "v24.0"}}
+	s, err := cs.NewSpool(log.NewNopLogger(), "pixel-1", EventsOptions{}, queue, deadLetters, SpoolConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, queue, deadLetters
+}
+
+func newSpoolEvent(id string) types.ServerEvent {
+	return types.NewServerEvent("Lead", id, types.UnixTime(time.Now().Unix()), types.PhysicalStore)
+}
+
+func TestSpoolDeduplicatesAndFlushes(t *testing.T) {
+	var sent []string
+	s, queue, _ := newTestSpool(t, func(req eventsRequest) (int, string) {
+		for _, e := range req.Data {
+			sent = append(sent, e.EventID)
+		}
+
+		return http.StatusOK, fmt.Sprintf(`{"events_received":%d}`, len(req.Data))
+	})
+
+	if err := s.Send(newSpoolEvent("a"), newSpoolEvent("b"), newSpoolEvent("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(newSpoolEvent("b")); err != nil {
+		t.Fatal(err)
+	}
+	if queue.Len() != 2 {
+		t.Fatalf("queue length = %d, want 2", queue.Len())
+	}
+
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sent) != "[a b]" || queue.Len() != 0 {
+		t.Fatalf("sent = %v with %d queued, want [a b] with 0 queued", sent, queue.Len())
+	}
+
+	// delivered events are no longer deduplicated
+	if err := s.Send(newSpoolEvent("a")); err != nil {
+		t.Fatal(err)
+	}
+	if queue.Len() != 1 {
+		t.Fatalf("queue length = %d, want 1", queue.Len())
+	}
+}
+
+func TestSpoolRetriesAndDeadLetters(t *testing.T) {
+	fail := true
+	s, queue, deadLetters := newTestSpool(t, func(req eventsRequest) (int, string) {
+		if req.Data[0].EventID == "permanent" {
+			return http.StatusBadRequest, `{"error":{"message":"Invalid parameter","code":100}}`
+		} else if fail {
+			return http.StatusInternalServerError, `{}`
+		}
+
+		return http.StatusOK, `{"events_received":1}`
+	})
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	expired := newSpoolEvent("expired")
+	expired.EventTime = types.UnixTime(now.Add(-63 * 24 * time.Hour).Unix())
+	if err := s.Send(newSpoolEvent("retry"), expired); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	queued, _ := queue.Peek(queue.Len())
+	if len(queued) != 1 || queued[0].Event.EventID != "retry" || queued[0].Attempts != 1 || !queued[0].NotBefore.After(now) {
+		t.Fatalf("unexpected queue: %+v", queued)
+	}
+	dead, _ := deadLetters.Peek(deadLetters.Len())
+	if len(dead) != 1 || dead[0].Event.EventID != "expired" {
+		t.Fatalf("unexpected dead letters: %+v", dead)
+	}
+
+	// the retry is not due yet
+	fail = false
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if queue.Len() != 1 || s.due() {
+		t.Fatalf("retried before NotBefore")
+	}
+
+	now = now.Add(time.Hour)
+	if !s.due() {
+		t.Fatal("retry should be due")
+	}
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if queue.Len() != 0 {
+		t.Fatalf("queue length = %d, want 0", queue.Len())
+	}
+
+	if err := s.Send(newSpoolEvent("permanent")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	dead, _ = deadLetters.Peek(deadLetters.Len())
+	if queue.Len() != 0 || len(dead) != 2 || dead[1].Event.EventID != "permanent" || dead[1].Attempts != 1 {
+		t.Fatalf("unexpected dead letters: %+v", dead)
+	}
+}
+
+func TestFileEventQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	q, err := NewFileEventQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if err = q.Append(QueuedEvent{Event: newSpoolEvent(id), Enqueued: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = q.Remove(1); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a crash during an append
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"event":{"event_na`)
+	f.Close()
+
+	q, err = NewFileEventQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued, _ := q.Peek(10)
+	if len(queued) != 2 || queued[0].Event.EventID != "b" || queued[1].Event.EventID != "c" {
+		t.Fatalf("unexpected queue after reopening: %+v", queued)
+	}
+}
+
+func TestSpoolConfigDefaults(t *testing.T) {
+	if cfg := (SpoolConfig{MaxDelay: time.Nanosecond}).withDefaults(); cfg.MaxDelay != minSpoolDelay {
+		t.Fatalf("MaxDelay = %s, want %s", cfg.MaxDelay, minSpoolDelay)
+	}
+	if cfg := (SpoolConfig{}).withDefaults(); cfg.MaxDelay != 10*time.Second || cfg.BatchSize != MaxEventsPerRequest {
+		t.Fatalf("unexpected defaults %+v", cfg)
+	}
+}
@@ -0,0 +1,83 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+func TestConversionsSendChunksAndValidates(t *testing.T) {
+	now := types.UnixTime(time.Now().Unix())
+	events := make(types.ServerEvents, 2002)
+	for i := range events {
+		events[i] = types.NewServerEvent("Purchase", fmt.Sprintf("event-%d", i), now, types.PhysicalStore).
+			WithCustomData(types.NewCustomData().WithValue(9.99, "EUR"))
+	}
+	events[5].EventName = ""
+
+	var chunkSizes []int
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: conversionsRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if request.URL.Path != "/v24.0/pixel-1/events" {
+			t.Fatalf("request path = %q, want %q", request.URL.Path, "/v24.0/pixel-1/events")
+		}
+		req := eventsRequest{}
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Fatalf("decode events request: %v", err)
+		}
+		if req.TestEventCode != "TEST123" {
+			t.Fatalf("test_event_code = %q, want %q", req.TestEventCode, "TEST123")
+		}
+		chunkSizes = append(chunkSizes, len(req.Data))
+		body := fmt.Sprintf(`{"events_received":%d,"messages":[],"fbtrace_id":"trace"}`, len(req.Data))
+		if len(chunkSizes) == 2 {
+			body = `{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &ConversionsService{c: client, v: Version{Name: "v24.0"}}
+
+	res, err := service.Send(context.Background(), "pixel-1", events, EventsOptions{TestEventCode: "TEST123"})
+	if fmt.Sprint(chunkSizes) != "[1000 1000 1]" {
+		t.Fatalf("chunk sizes = %v, want [1000 1000 1]", chunkSizes)
+	}
+	if len(res) != 3 || res[0].EventsReceived != 1000 || res[1].Err == nil || res[2].FbtraceID != "trace" {
+		t.Fatalf("unexpected responses: %+v", res)
+	}
+	if res[0].Indices[5] != 6 || res[1].Indices[0] != 1001 || fmt.Sprint(res[2].Indices) != "[2001]" {
+		t.Fatalf("unexpected chunk indices %v %v %v", res[0].Indices[5], res[1].Indices[0], res[2].Indices)
+	}
+
+	sendErr := &SendError{}
+	if !errors.As(err, &sendErr) {
+		t.Fatalf("err = %v, want *SendError", err)
+	}
+	if len(sendErr.Events) != 1 || sendErr.Events[0].Index != 5 || sendErr.Events[0].EventID != "event-5" {
+		t.Fatalf("unexpected event errors: %+v", sendErr.Events)
+	}
+	if len(sendErr.Chunks) != 1 || sendErr.Chunks[0].Indices[0] != 1001 {
+		t.Fatalf("unexpected chunk errors: %+v", sendErr.Chunks)
+	}
+}
+
+type conversionsRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f conversionsRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
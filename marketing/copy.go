@@ -0,0 +1,283 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// Rename strategies of copies.
+const (
+	RenameDeep     = "DEEP_RENAME"
+	RenameTopLevel = "ONLY_TOP_LEVEL_RENAME"
+	RenameNone     = "NO_RENAME"
+)
+
+// Status options of copies.
+const (
+	CopyStatusActive    = "ACTIVE"
+	CopyStatusPaused    = "PAUSED"
+	CopyStatusInherited = "INHERITED_FROM_SOURCE"
+)
+
+// defaultCopyPollInterval is used by async copies if CopyOptions.PollInterval is not set.
+const defaultCopyPollInterval = 5 * time.Second
+
+// maxSyncCopyChildren is the number of child objects the copies endpoints deep copy synchronously.
+const maxSyncCopyChildren = 3
+
+// RenameOptions controls the names of copies.
+type RenameOptions struct {
+	Strategy string `json:"rename_strategy,omitempty"`
+	Prefix   string `json:"rename_prefix,omitempty"`
+	Suffix   string `json:"rename_suffix,omitempty"`
+}
+
+// CopyOptions https://developers.facebook.com/docs/marketing-api/reference/ad-campaign-group/copies/
+type CopyOptions struct {
+	// DeepCopy also copies the adsets and ads of campaigns and the ads of adsets.
+	DeepCopy bool
+	Rename   *RenameOptions
+	// StatusOption is one of the CopyStatus constants.
+	StatusOption string
+	// StartTime and EndTime override the schedule of copied adsets, they are not supported by ads.
+	StartTime time.Time
+	EndTime   time.Time
+	// ParentID is the campaign of copied adsets or the adset of copied ads, the copy is
+	// created in the parent of the source if it is empty. Campaigns don't have a parent.
+	ParentID string
+	// Async sends the copy as an async batch request. The copies endpoints only deep copy
+	// up to 3 child objects synchronously, so deep copies of more children are always async.
+	Async bool
+	// PollInterval is the interval of checking async copies, defaults to 5 seconds.
+	PollInterval time.Duration
+}
+
+// copyResponse is the result of a copy, only the id of the copied object type is set.
+type copyResponse struct {
+	CopiedCampaignID string `json:"copied_campaign_id"`
+	CopiedAdsetID    string `json:"copied_adset_id"`
+	CopiedAdID       string `json:"copied_ad_id"`
+	AdObjectIDs      []struct {
+		AdObjectType string `json:"ad_object_type"`
+		SourceID     string `json:"source_id"`
+		CopiedID     string `json:"copied_id"`
+	} `json:"ad_object_ids"`
+	fb.ErrorContainer
+}
+
+// ids returns the id of the copy of each source object.
+func (cr copyResponse) ids(sourceID string) (map[string]string, error) {
+	ids := map[string]string{}
+	for _, o := range cr.AdObjectIDs {
+		ids[o.SourceID] = o.CopiedID
+	}
+	for _, id := range []string{cr.CopiedCampaignID, cr.CopiedAdsetID, cr.CopiedAdID} {
+		if id != "" {
+			ids[sourceID] = id
+		}
+	}
+	if ids[sourceID] == "" {
+		return nil, fmt.Errorf("copying %s failed", sourceID)
+	}
+
+	return ids, nil
+}
+
+// Copy copies a campaign and, with opts.DeepCopy, its adsets and ads.
+// It returns the id of the copy of every copied source object.
+func (cs *CampaignService) Copy(ctx context.Context, id string, opts CopyOptions) (map[string]string, error) {
+	if opts.ParentID != "" {
+		return nil, errors.New("campaigns cannot be copied into another parent")
+	}
+
+	return copyObject(ctx, cs.c, cs.v, id, "", []string{"adsets", "ads"}, opts)
+}
+
+// Copy copies an adset and, with opts.DeepCopy, its ads.
+// It returns the id of the copy of every copied source object.
+func (as *AdsetService) Copy(ctx context.Context, id string, opts CopyOptions) (map[string]string, error) {
+	return copyObject(ctx, as.c, as.v, id, "campaign_id", []string{"ads"}, opts)
+}
+
+// Copy copies an ad and returns the id of the copy mapped by the source id.
+func (as *AdService) Copy(ctx context.Context, id string, opts CopyOptions) (map[string]string, error) {
+	if opts.DeepCopy {
+		return nil, errors.New("ads cannot be deep copied")
+	} else if !opts.StartTime.IsZero() || !opts.EndTime.IsZero() {
+		return nil, errors.New("ads cannot be copied with start or end time")
+	}
+
+	return copyObject(ctx, as.c, as.v, id, "adset_id", nil, opts)
+}
+
+// copyParams returns the parameters of a copy request, parentField is the name of the ParentID parameter.
+func copyParams(parentField string, opts CopyOptions) map[string]interface{} {
+	params := map[string]interface{}{}
+	if opts.DeepCopy {
+		params["deep_copy"] = true
+	}
+	if opts.Rename != nil {
+		params["rename_options"] = opts.Rename
+	}
+	if opts.StatusOption != "" {
+		params["status_option"] = opts.StatusOption
+	}
+	if !opts.StartTime.IsZero() {
+		params["start_time"] = opts.StartTime.Unix()
+	}
+	if !opts.EndTime.IsZero() {
+		params["end_time"] = opts.EndTime.Unix()
+	}
+	if opts.ParentID != "" {
+		params[parentField] = opts.ParentID
+	}
+
+	return params
+}
+
+// copyObject copies the object, childEdges are the edges of the children copied by deep copies.
+func copyObject(ctx context.Context, c *fb.Client, v Version, id, parentField string, childEdges []string, opts CopyOptions) (map[string]string, error) {
+	if id == "" {
+		return nil, errors.New("cannot copy without id")
+	}
+	switch opts.StatusOption {
+	case "", CopyStatusActive, CopyStatusPaused, CopyStatusInherited:
+	default:
+		return nil, fmt.Errorf("unknown status option '%s'", opts.StatusOption)
+	}
+	params := copyParams(parentField, opts)
+
+	if opts.DeepCopy && !opts.Async {
+		children := 0
+		for _, edge := range childEdges {
+			ids := []IDContainer{}
+			err := c.GetList(ctx, fb.NewRoute(v.Name, "/%s/%s", id, edge).Fields("id").Limit(1000).String(), &ids)
+			if err != nil {
+				return nil, fmt.Errorf("counting %s of %s: %w", edge, id, err)
+			}
+			children += len(ids)
+		}
+		opts.Async = children > maxSyncCopyChildren
+	}
+	if opts.Async {
+		return copyObjectAsync(ctx, c, v, id, params, opts.PollInterval)
+	}
+
+	res := copyResponse{}
+	err := c.PostJSON(ctx, fb.NewRoute(v.Name, "/%s/copies", id).String(), params, &res)
+	if err != nil {
+		return nil, err
+	} else if err = res.GetError(); err != nil {
+		return nil, err
+	}
+
+	return res.ids(id)
+}
+
+// asyncRequestSet is the status of an async batch request.
+type asyncRequestSet struct {
+	ID              string `json:"id"`
+	IsCompleted     bool   `json:"is_completed"`
+	TotalCount      int    `json:"total_count"`
+	SuccessCount    int    `json:"success_count"`
+	ErrorCount      int    `json:"error_count"`
+	InProgressCount int    `json:"in_progress_count"`
+}
+
+// asyncRequest is a single request of an async batch request.
+type asyncRequest struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Status string          `json:"status"`
+	Result json.RawMessage `json:"result"`
+}
+
+// copyObjectAsync sends the copy as an async batch request of the account of the source object and waits until it's completed.
+func copyObjectAsync(ctx context.Context, c *fb.Client, v Version, id string, params map[string]interface{}, interval time.Duration) (map[string]string, error) {
+	source := &struct {
+		AccountID string `json:"account_id"`
+	}{}
+	err := c.GetJSON(ctx, fb.NewRoute(v.Name, "/%s", id).Fields("account_id").String(), source)
+	if err != nil {
+		return nil, err
+	} else if source.AccountID == "" {
+		return nil, fmt.Errorf("did not find account of %s", id)
+	}
+
+	body := url.Values{}
+	for k, p := range params {
+		if s, ok := p.(string); ok {
+			body.Set(k, s)
+			continue
+		}
+		b, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		body.Set(k, string(b))
+	}
+
+	set := &asyncRequestSet{}
+	err = c.PostJSON(ctx, fb.NewRoute(v.Name, "/act_%s/async_batch_requests", source.AccountID).String(), map[string]interface{}{
+		"name": "copy " + id,
+		"adbatch": []map[string]string{{
+			"name":         id,
+			"relative_url": id + "/copies",
+			"body":         body.Encode(),
+		}},
+	}, set)
+	if err != nil {
+		return nil, err
+	} else if set.ID == "" {
+		return nil, fmt.Errorf("creating async copy of %s failed", id)
+	}
+
+	if interval <= 0 {
+		interval = defaultCopyPollInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		err = c.GetJSON(ctx, fb.NewRoute(v.Name, "/%s", set.ID).Fields("id", "is_completed", "total_count", "success_count", "error_count", "in_progress_count").String(), set)
+		if err != nil {
+			return nil, err
+		} else if set.IsCompleted {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	requests := []asyncRequest{}
+	err = c.GetList(ctx, fb.NewRoute(v.Name, "/%s/requests", set.ID).Fields("id", "name", "status", "result").String(), &requests)
+	if err != nil {
+		return nil, err
+	} else if len(requests) != 1 {
+		return nil, fmt.Errorf("async copy of %s has %d requests", id, len(requests))
+	}
+
+	res := copyResponse{}
+	if len(requests[0].Result) > 0 {
+		err = json.Unmarshal(requests[0].Result, &res)
+		if err != nil {
+			return nil, fmt.Errorf("decoding async copy result of %s: %w", id, err)
+		}
+	}
+	if err = res.GetError(); err != nil {
+		return nil, err
+	} else if requests[0].Status != "SUCCESS" {
+		return nil, fmt.Errorf("async copy of %s has status %s", id, requests[0].Status)
+	}
+
+	return res.ids(id)
+}
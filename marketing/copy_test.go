@@ -0,0 +1,129 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestCopy(t *testing.T) {
+	polls := 0
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: copyRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		params := map[string]interface{}{}
+		if request.Method == http.MethodPost {
+			if err := json.NewDecoder(request.Body).Decode(&params); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		body := ""
+		switch request.URL.Path {
+		case "/v24.0/c1/copies":
+			if params["deep_copy"] != true || params["status_option"] != CopyStatusPaused || params["start_time"] != float64(1700000000) {
+				t.Fatalf("unexpected params %v", params)
+			}
+			if ro, _ := params["rename_options"].(map[string]interface{}); ro["rename_suffix"] != " copy" || ro["rename_strategy"] != RenameDeep {
+				t.Fatalf("unexpected rename options %v", params["rename_options"])
+			}
+			body = `{"copied_campaign_id":"c2","ad_object_ids":[{"ad_object_type":"campaign","source_id":"c1","copied_id":"c2"},` +
+				`{"ad_object_type":"adset","source_id":"as1","copied_id":"as2"}]}`
+		case "/v24.0/c1/adsets":
+			body = `{"data":[{"id":"as1"}]}`
+		case "/v24.0/c1/ads":
+			body = `{"data":[{"id":"ad1"}]}`
+		case "/v24.0/c3/adsets":
+			body = `{"data":[{"id":"as5"},{"id":"as6"}]}`
+		case "/v24.0/c3/ads":
+			body = `{"data":[{"id":"ad5"},{"id":"ad6"}]}`
+		case "/v24.0/as1", "/v24.0/c3":
+			body = `{"account_id":"42"}`
+		case "/v24.0/act_42/async_batch_requests":
+			adbatch := params["adbatch"].([]interface{})[0].(map[string]interface{})
+			form, err := url.ParseQuery(adbatch["body"].(string))
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch {
+			case adbatch["relative_url"] == "c3/copies" && form.Get("deep_copy") == "true":
+				body = `{"id":"set2"}`
+			case adbatch["relative_url"] == "as1/copies" && form.Get("campaign_id") == "c9" && form.Get("deep_copy") == "true":
+				body = `{"id":"set1"}`
+			default:
+				t.Fatalf("unexpected async request %v", adbatch)
+			}
+		case "/v24.0/set2":
+			body = `{"id":"set2","is_completed":true}`
+		case "/v24.0/set2/requests":
+			body = `{"data":[{"id":"r2","status":"SUCCESS","result":{"copied_campaign_id":"c4"}}]}`
+		case "/v24.0/set1":
+			polls++
+			body = `{"id":"set1","is_completed":` + map[bool]string{true: "true", false: "false"}[polls > 1] + `}`
+		case "/v24.0/set1/requests":
+			body = `{"data":[{"id":"r1","status":"SUCCESS","result":{"copied_adset_id":"as3","ad_object_ids":[{"ad_object_type":"ad","source_id":"ad1","copied_id":"ad3"}]}}]}`
+		default:
+			t.Fatalf("unexpected request %s %s", request.Method, request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	v := Version{Name: "v24.0"}
+	ctx := context.Background()
+
+	ids, err := (&CampaignService{c: client, v: v}).Copy(ctx, "c1", CopyOptions{
+		DeepCopy:     true,
+		Rename:       &RenameOptions{Strategy: RenameDeep, Suffix: " copy"},
+		StatusOption: CopyStatusPaused,
+		StartTime:    time.Unix(1700000000, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"c1": "c2", "as1": "as2"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+
+	ids, err = (&AdsetService{c: client, v: v}).Copy(ctx, "as1", CopyOptions{DeepCopy: true, ParentID: "c9", Async: true, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"as1": "as3", "ad1": "ad3"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+	if polls != 2 {
+		t.Fatalf("polled %d times, want 2", polls)
+	}
+
+	// more than 3 children are copied async without setting Async
+	ids, err = (&CampaignService{c: client, v: v}).Copy(ctx, "c3", CopyOptions{DeepCopy: true, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"c3": "c4"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+
+	if _, err := (&AdService{c: client, v: v}).Copy(ctx, "ad1", CopyOptions{DeepCopy: true}); err == nil {
+		t.Fatal("expected error for deep copy of ad")
+	}
+}
+
+type copyRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f copyRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -1,4 +1,4 @@
-package v22
+package marketing
 
 import (
 	"context"
@@ -11,6 +11,7 @@ import (
 // CustomConversionService contains all methods for working on custom conversions.
 type CustomConversionService struct {
 	c *fb.Client
+	v Version
 }
 
 // Create uploads a new custom conversion and returns the id of the custom conversion.
@@ -22,7 +23,7 @@ func (ccs *CustomConversionService) Create(ctx context.Context, businessID strin
 	}
 
 	res := &fb.MinimalResponse{}
-	err := ccs.c.PostJSON(ctx, fb.NewRoute(Version, "/%s/customconversions", businessID).String(), cc, res)
+	err := ccs.c.PostJSON(ctx, fb.NewRoute(ccs.v.Name, "/%s/customconversions", businessID).String(), cc, res)
 	if err != nil {
 		return "", err
 	} else if err = res.GetError(); err != nil {
@@ -37,7 +38,7 @@ func (ccs *CustomConversionService) Create(ctx context.Context, businessID strin
 // List returns all custom conversions for the specified account.
 func (ccs *CustomConversionService) List(ctx context.Context, act string) ([]CustomConversion, error) {
 	customConversions := []CustomConversion{}
-	route := fb.NewRoute(Version, "/act_%s/customconversions", act).
+	route := fb.NewRoute(ccs.v.Name, "/act_%s/customconversions", act).
 		Limit(250).
 		Fields(
 			"id",
@@ -0,0 +1,226 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// Problem types of a DiagnosticsReport.
+const (
+	ProblemDisapproved       = "disapproved"
+	ProblemLearningLimited   = "learning_limited"
+	ProblemBilling           = "billing"
+	ProblemAudienceTooNarrow = "audience_too_narrow"
+	// ProblemAccountDisabled is reported for ad accounts that were disabled, e.g. for policy violations.
+	ProblemAccountDisabled = "account_disabled"
+	// ProblemOther are issues that don't fall into any of the other types.
+	ProblemOther = "other"
+)
+
+// Statuses of LearningStageInfo, FAIL is shown as "Learning limited" in the Ads Manager.
+const (
+	LearningStageLearning = "LEARNING"
+	LearningStageSuccess  = "SUCCESS"
+	LearningStageFail     = "FAIL"
+)
+
+// account_status values of ad accounts with delivery problems, all but DISABLED are billing problems.
+const (
+	accountStatusDisabled          = 2
+	accountStatusUnsettled         = 3
+	accountStatusPendingSettlement = 8
+	accountStatusInGracePeriod     = 9
+)
+
+var (
+	campaignDiagnosticsFields = []string{"id", "name", "effective_status", "configured_status", "issues_info", "recommendations"}
+	adsetDiagnosticsFields    = append(append([]string{}, campaignDiagnosticsFields...), "campaign_id", "learning_stage_info")
+	adDiagnosticsFields       = append(append([]string{}, campaignDiagnosticsFields...), "campaign_id", "adset_id", "ad_review_feedback")
+)
+
+// DeliveryIssue is an entry of issues_info.
+type DeliveryIssue struct {
+	ErrorCode    int    `json:"error_code"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	ErrorSummary string `json:"error_summary,omitempty"`
+	ErrorType    string `json:"error_type,omitempty"`
+	Level        string `json:"level,omitempty"`
+}
+
+// DeliveryRecommendation https://developers.facebook.com/docs/marketing-api/reference/ad-recommendation/
+type DeliveryRecommendation struct {
+	BlameField string `json:"blame_field,omitempty"`
+	Code       int    `json:"code"`
+	Confidence string `json:"confidence,omitempty"`
+	Importance string `json:"importance,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Title      string `json:"title,omitempty"`
+}
+
+// LearningStageInfo is the learning phase of an adset.
+type LearningStageInfo struct {
+	Status             string   `json:"status"`
+	Conversions        int      `json:"conversions,omitempty"`
+	LastSigEditTS      int64    `json:"last_sig_edit_ts,omitempty"`
+	AttributionWindows []string `json:"attribution_windows,omitempty"`
+}
+
+// AdReviewFeedback contains the reasons of ad review rejections, keyed by policy.
+type AdReviewFeedback struct {
+	Global            map[string]string            `json:"global,omitempty"`
+	PlacementSpecific map[string]map[string]string `json:"placement_specific,omitempty"`
+}
+
+// DeliveryDiagnostics contains the delivery information of a campaign, adset or ad.
+type DeliveryDiagnostics struct {
+	Type              string                   `json:"-"`
+	ID                string                   `json:"id"`
+	Name              string                   `json:"name,omitempty"`
+	CampaignID        string                   `json:"campaign_id,omitempty"`
+	AdsetID           string                   `json:"adset_id,omitempty"`
+	EffectiveStatus   EffectiveStatus          `json:"effective_status,omitempty"`
+	ConfiguredStatus  string                   `json:"configured_status,omitempty"`
+	IssuesInfo        []DeliveryIssue          `json:"issues_info,omitempty"`
+	Recommendations   []DeliveryRecommendation `json:"recommendations,omitempty"`
+	LearningStageInfo *LearningStageInfo       `json:"learning_stage_info,omitempty"`
+	AdReviewFeedback  *AdReviewFeedback        `json:"ad_review_feedback,omitempty"`
+}
+
+// Problems returns the problem types of the object. Narrow audiences are detected by the texts of
+// the issues and recommendations as there are no documented codes for them.
+func (dd DeliveryDiagnostics) Problems() []string {
+	problems := map[string]bool{}
+	if dd.EffectiveStatus == EffectiveStatusDisapproved ||
+		(dd.AdReviewFeedback != nil && (len(dd.AdReviewFeedback.Global) > 0 || len(dd.AdReviewFeedback.PlacementSpecific) > 0)) {
+		problems[ProblemDisapproved] = true
+	}
+	if dd.LearningStageInfo != nil && dd.LearningStageInfo.Status == LearningStageFail {
+		problems[ProblemLearningLimited] = true
+	}
+	if dd.EffectiveStatus == EffectiveStatusPendingBillingInfo {
+		problems[ProblemBilling] = true
+	}
+	for _, i := range dd.IssuesInfo {
+		text := strings.ToLower(i.ErrorSummary + " " + i.ErrorMessage)
+		switch {
+		case isNarrowAudience(text):
+			problems[ProblemAudienceTooNarrow] = true
+		case strings.Contains(text, "payment") || strings.Contains(text, "billing"):
+			problems[ProblemBilling] = true
+		default:
+			problems[ProblemOther] = true
+		}
+	}
+	for _, r := range dd.Recommendations {
+		if isNarrowAudience(strings.ToLower(r.Title + " " + r.Message)) {
+			problems[ProblemAudienceTooNarrow] = true
+		}
+	}
+
+	res := make([]string, 0, len(problems))
+	for p := range problems {
+		res = append(res, p)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+func isNarrowAudience(text string) bool {
+	return strings.Contains(text, "audience") && (strings.Contains(text, "narrow") || strings.Contains(text, "too small"))
+}
+
+// GetDiagnostics returns the delivery diagnostics of a campaign.
+func (cs *CampaignService) GetDiagnostics(ctx context.Context, id string) (*DeliveryDiagnostics, error) {
+	return getDiagnostics(ctx, cs.c, cs.v, ObjectTypeCampaign, id, campaignDiagnosticsFields)
+}
+
+// GetDiagnostics returns the delivery diagnostics of an adset including its learning phase.
+func (as *AdsetService) GetDiagnostics(ctx context.Context, id string) (*DeliveryDiagnostics, error) {
+	return getDiagnostics(ctx, as.c, as.v, ObjectTypeAdset, id, adsetDiagnosticsFields)
+}
+
+// GetDiagnostics returns the delivery diagnostics of an ad including its review feedback.
+func (as *AdService) GetDiagnostics(ctx context.Context, id string) (*DeliveryDiagnostics, error) {
+	return getDiagnostics(ctx, as.c, as.v, ObjectTypeAd, id, adDiagnosticsFields)
+}
+
+func getDiagnostics(ctx context.Context, c *fb.Client, v Version, objectType, id string, fields []string) (*DeliveryDiagnostics, error) {
+	res := &DeliveryDiagnostics{}
+	err := c.GetJSON(ctx, fb.NewRoute(v.Name, "/%s", id).Fields(fields...).String(), res)
+	if err != nil {
+		if fb.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	res.Type = objectType
+
+	return res, nil
+}
+
+// DiagnosticsReport groups the delivery problems of an account.
+type DiagnosticsReport struct {
+	AccountID string
+	// Problems contains the ids of the affected objects by problem type. Billing problems
+	// and the disabling of the account itself are reported with the id act_<account id>.
+	Problems map[string][]string
+	// Objects contains the diagnostics of the objects with problems by id.
+	Objects map[string]DeliveryDiagnostics
+}
+
+// DiagnoseAccount reads the diagnostics of all campaigns, adsets and ads of an account and groups their problems by type.
+func (s *Service) DiagnoseAccount(ctx context.Context, act string) (*DiagnosticsReport, error) {
+	report := &DiagnosticsReport{
+		AccountID: act,
+		Problems:  map[string][]string{},
+		Objects:   map[string]DeliveryDiagnostics{},
+	}
+
+	account := &struct {
+		AccountStatus int `json:"account_status"`
+	}{}
+	err := s.Client.GetJSON(ctx, fb.NewRoute(s.v.Name, "/act_%s", act).Fields("account_status").String(), account)
+	if err != nil {
+		return nil, err
+	}
+	switch account.AccountStatus {
+	case accountStatusDisabled:
+		report.Problems[ProblemAccountDisabled] = append(report.Problems[ProblemAccountDisabled], "act_"+act)
+	case accountStatusUnsettled, accountStatusPendingSettlement, accountStatusInGracePeriod:
+		report.Problems[ProblemBilling] = append(report.Problems[ProblemBilling], "act_"+act)
+	}
+
+	for _, level := range []struct {
+		objectType, edge string
+		fields           []string
+	}{
+		{ObjectTypeCampaign, "campaigns", campaignDiagnosticsFields},
+		{ObjectTypeAdset, "adsets", adsetDiagnosticsFields},
+		{ObjectTypeAd, "ads", adDiagnosticsFields},
+	} {
+		res := []DeliveryDiagnostics{}
+		err = s.Client.GetList(ctx, fb.NewRoute(s.v.Name, "/act_%s/%s", act, level.edge).Fields(level.fields...).Limit(500).String(), &res)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", level.edge, err)
+		}
+		for _, dd := range res {
+			dd.Type = level.objectType
+			problems := dd.Problems()
+			if len(problems) == 0 {
+				continue
+			}
+			report.Objects[dd.ID] = dd
+			for _, p := range problems {
+				report.Problems[p] = append(report.Problems[p], dd.ID)
+			}
+		}
+	}
+
+	return report, nil
+}
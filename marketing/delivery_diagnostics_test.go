@@ -0,0 +1,104 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestDeliveryDiagnosticsProblems(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		dd   DeliveryDiagnostics
+		want []string
+	}{
+		{"healthy", DeliveryDiagnostics{EffectiveStatus: EffectiveStatusActive, LearningStageInfo: &LearningStageInfo{Status: LearningStageSuccess}}, []string{}},
+		{"disapproved", DeliveryDiagnostics{AdReviewFeedback: &AdReviewFeedback{Global: map[string]string{"Tobacco": "not allowed"}}}, []string{ProblemDisapproved}},
+		{"learning limited", DeliveryDiagnostics{LearningStageInfo: &LearningStageInfo{Status: LearningStageFail}}, []string{ProblemLearningLimited}},
+		{"billing", DeliveryDiagnostics{EffectiveStatus: EffectiveStatusPendingBillingInfo}, []string{ProblemBilling}},
+		{"issues", DeliveryDiagnostics{
+			IssuesInfo:      []DeliveryIssue{{ErrorCode: 1, ErrorSummary: "Payment method failed"}, {ErrorCode: 2, ErrorSummary: "Unknown"}},
+			Recommendations: []DeliveryRecommendation{{Title: "Audience Too Narrow", BlameField: "targeting"}},
+		}, []string{ProblemAudienceTooNarrow, ProblemBilling, ProblemOther}},
+	} {
+		if got := tc.dd.Problems(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: Problems() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDiagnoseAccount(t *testing.T) {
+	accountStatus := 3
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: deliveryDiagnosticsRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		body := ""
+		switch request.URL.Path {
+		case "/v24.0/act_1":
+			body = fmt.Sprintf(`{"account_status":%d}`, accountStatus)
+		case "/v24.0/act_1/campaigns":
+			body = `{"data":[{"id":"c1","effective_status":"ACTIVE"}]}`
+		case "/v24.0/act_1/adsets":
+			if !strings.Contains(request.URL.Query().Get("fields"), "learning_stage_info") {
+				t.Fatalf("missing learning_stage_info in %s", request.URL)
+			}
+			body = `{"data":[{"id":"as1","campaign_id":"c1","effective_status":"ACTIVE","learning_stage_info":{"status":"FAIL","conversions":3}}]}`
+		case "/v24.0/act_1/ads":
+			body = `{"data":[{"id":"a1","campaign_id":"c1","adset_id":"as1","effective_status":"DISAPPROVED",` +
+				`"ad_review_feedback":{"global":{"Personal Attributes":"Ads must not assert personal attributes."}}},` +
+				`{"id":"a2","campaign_id":"c1","adset_id":"as1","effective_status":"ACTIVE"}]}`
+		default:
+			t.Fatalf("unexpected request %s", request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &Service{Client: client, v: Version{Name: "v24.0"}}
+
+	report, err := service.DiagnoseAccount(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		ProblemBilling:         {"act_1"},
+		ProblemLearningLimited: {"as1"},
+		ProblemDisapproved:     {"a1"},
+	}
+	if !reflect.DeepEqual(report.Problems, want) {
+		t.Fatalf("Problems = %v, want %v", report.Problems, want)
+	}
+	if len(report.Objects) != 2 || report.Objects["as1"].Type != ObjectTypeAdset || report.Objects["as1"].LearningStageInfo.Conversions != 3 {
+		t.Fatalf("unexpected objects %+v", report.Objects)
+	}
+
+	for status, problem := range map[int]string{2: ProblemAccountDisabled, 8: ProblemBilling, 9: ProblemBilling} {
+		accountStatus = status
+		report, err = service.DiagnoseAccount(context.Background(), "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := report.Problems[problem]; len(got) != 1 || got[0] != "act_1" {
+			t.Fatalf("account_status %d: Problems = %v, want act_1 as %s", status, report.Problems, problem)
+		}
+		if status == 2 && len(report.Problems[ProblemBilling]) != 0 {
+			t.Fatalf("account_status 2 reported as billing problem: %v", report.Problems)
+		}
+	}
+}
+
+type deliveryDiagnosticsRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f deliveryDiagnosticsRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -9,6 +9,7 @@ import (
 // EventService contains all methods for working on events.
 type EventService struct {
 	c *fb.Client
+	v Version
 }
 
 // SimpleList returns event names for a given pixel id.
@@ -20,7 +21,7 @@ func (es *EventService) SimpleList(ctx context.Context, pixelID string) ([]strin
 	}
 
 	res := []events{}
-	route := fb.NewRoute(Version, "/%s/stats", pixelID).
+	route := fb.NewRoute(es.v.Name, "/%s/stats", pixelID).
 		Limit(250).
 		Fields("data{value}").
 		Aggregation("event")
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -18,6 +18,7 @@ var regexImageFilename = regexp.MustCompile(`^\d+_(\d+)_\d+_[a-z]\.([a-z]+)$`)
 // ImageService works with ad images.
 type ImageService struct {
 	c *fb.Client
+	v Version
 }
 
 // ReadList writes all ad images from an account to res.
@@ -27,7 +28,7 @@ func (is *ImageService) ReadList(ctx context.Context, act string, res chan<- Ima
 	wg.Go(func() error {
 		defer close(jres)
 
-		return is.c.ReadList(ctx, fb.NewRoute(Version, "/act_%s/adimages", act).Fields("name", "hash", "url", "width", "height").Limit(500).String(), jres)
+		return is.c.ReadList(ctx, fb.NewRoute(is.v.Name, "/act_%s/adimages", act).Fields("name", "hash", "url", "width", "height").Limit(500).String(), jres)
 	})
 	wg.Go(func() error {
 		for e := range jres {
@@ -54,7 +55,7 @@ func (is *ImageService) ReadList(ctx context.Context, act string, res chan<- Ima
 // Upload uploads an image to Facebook.
 func (is *ImageService) Upload(ctx context.Context, act, name string, r io.Reader) (*Image, error) {
 	fur := &fileUploadResponse{}
-	err := is.c.UploadFile(ctx, fb.NewRoute(Version, "/act_%s/adimages", act).String(), name, r, nil, fur)
+	err := is.c.UploadFile(ctx, fb.NewRoute(is.v.Name, "/act_%s/adimages", act).String(), name, r, nil, fur)
 	if err != nil {
 		return nil, err
 	}
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -17,13 +17,15 @@ import (
 type InsightsService struct {
 	l log.Logger
 	c *fb.Client
+	v Version
 	*fb.StatsContainer
 }
 
-func newInsightsService(l log.Logger, c *fb.Client) *InsightsService {
+func newInsightsService(l log.Logger, c *fb.Client, v Version) *InsightsService {
 	return &InsightsService{
 		l:              l,
 		c:              c,
+		v:              v,
 		StatsContainer: fb.NewStatsContainer(),
 	}
 }
@@ -32,7 +34,7 @@ func newInsightsService(l log.Logger, c *fb.Client) *InsightsService {
 func (is *InsightsService) NewReport(account string) *InsightsRequest {
 	return &InsightsRequest{
 		InsightsService: is,
-		RouteBuilder:    fb.NewRoute(Version, "/act_%s/insights", account),
+		RouteBuilder:    fb.NewRoute(is.v.Name, "/act_%s/insights", account),
 	}
 }
 
@@ -40,7 +42,7 @@ func (is *InsightsService) NewReport(account string) *InsightsRequest {
 func (is *InsightsService) NewReportOfCampaign(campaignID string) *InsightsRequest {
 	return &InsightsRequest{
 		InsightsService: is,
-		RouteBuilder:    fb.NewRoute(Version, "/%s/insights", campaignID),
+		RouteBuilder:    fb.NewRoute(is.v.Name, "/%s/insights", campaignID),
 	}
 }
 
@@ -109,7 +111,7 @@ func (ir *InsightsRequest) generateReportAsync(ctx context.Context, c chan<- Ins
 
 	defer func() {
 		ir.StatsContainer.RemoveStats(run.ReportRunID)
-		url := fb.NewRoute(Version, "/%s", run.ReportRunID).String()
+		url := fb.NewRoute(ir.v.Name, "/%s", run.ReportRunID).String()
 		e := ir.c.Delete(ctx, url)
 		if e != nil {
 			_ = level.Warn(ir.l).Log("msg", "err deleting report run", "id", run.ReportRunID, "err", e, "url", url)
@@ -129,7 +131,7 @@ func (ir *InsightsRequest) generateReportAsync(ctx context.Context, c chan<- Ins
 		default:
 		}
 		run.IsRunning = false // field is omitted when it is false, so we need to set it to false manually
-		err = ir.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", run.ReportRunID).String(), run)
+		err = ir.c.GetJSON(ctx, fb.NewRoute(ir.v.Name, "/%s", run.ReportRunID).String(), run)
 		if err != nil {
 			return 0, err
 		}
@@ -151,7 +153,7 @@ func (ir *InsightsRequest) generateReportAsync(ctx context.Context, c chan<- Ins
 		}
 	}
 
-	url := fb.NewRoute(Version, "/%s/insights", run.ReportRunID).Limit(100).String()
+	url := fb.NewRoute(ir.v.Name, "/%s/insights", run.ReportRunID).Limit(100).String()
 	var count, impressions uint64
 	for url != "" {
 		resp := &struct {
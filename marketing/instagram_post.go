@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -29,7 +29,7 @@ var instaPostFields = []string{"id", "ig_id", "shortcode", "permalink", "owner",
 // GetClientPages returns all client pages.
 func (ps *PostService) ListInstagramPosts(ctx context.Context, igUserID string, c chan<- InstagramPost) (uint64, error) {
 	defer close(c)
-	url := fb.NewRoute(Version, "/%s/media", igUserID).Limit(100).Fields(instaPostFields...).String()
+	url := fb.NewRoute(ps.v.Name, "/%s/media", igUserID).Limit(100).Fields(instaPostFields...).String()
 	var count uint64
 	for url != "" {
 		resp := &struct {
@@ -55,7 +55,7 @@ func (ps *PostService) ListInstagramPosts(ctx context.Context, igUserID string,
 // ListOfInstagramUser returns an InstagramPostListCall for listing media of an IG user.
 func (ps *PostService) ListOfInstagramUser(igUserID string) *InstagramPostListCall {
 	return &InstagramPostListCall{
-		RouteBuilder: fb.NewRoute(Version, "/%s/media", igUserID).Fields(instaPostFields...).Limit(100),
+		RouteBuilder: fb.NewRoute(ps.v.Name, "/%s/media", igUserID).Fields(instaPostFields...).Limit(100),
 		c:            ps.c,
 	}
 }
@@ -78,7 +78,7 @@ func (ilc *InstagramPostListCall) Do(ctx context.Context) ([]InstagramPost, erro
 
 func (ps *PostService) GetInstagramPost(ctx context.Context, postID string) (*InstagramPost, error) {
 	res := InstagramPost{}
-	err := ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", postID).Fields(instaPostFields...).String(), &res)
+	err := ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s", postID).Fields(instaPostFields...).String(), &res)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +127,7 @@ var instaCommentFields = []string{"id", "text", "like_count", "timestamp"}
 
 func (ps *PostService) ListInstagramComments(ctx context.Context, postID string, c chan<- InstagramComment) (uint64, error) {
 	defer close(c)
-	url := fb.NewRoute(Version, "/%s/comments", postID).Limit(50).Fields(instaCommentFields...).String()
+	url := fb.NewRoute(ps.v.Name, "/%s/comments", postID).Limit(50).Fields(instaCommentFields...).String()
 	var count uint64
 	for url != "" {
 		resp := &struct {
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -68,7 +68,7 @@ func TestGetInstagramPermalinksByMediaIDs(t *testing.T) {
 			Request:    request,
 		}, nil
 	})}
-	service := &PostService{c: client}
+	service := &PostService{c: client, v: Version{Name: "v24.0"}}
 
 	got, err := service.GetInstagramPermalinksByMediaIDs(context.Background(), mediaIDs)
 	if err != nil {
@@ -160,7 +160,7 @@ func TestListInstagramCommentsByMediaIDs(t *testing.T) {
 			Request:    request,
 		}, nil
 	})}
-	service := &PostService{c: client}
+	service := &PostService{c: client, v: Version{Name: "v24.0"}}
 
 	got, err := service.ListInstagramCommentsByMediaIDs(context.Background(), mediaIDs)
 	if err != nil {
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -10,6 +10,7 @@ import (
 // InterestService works with ads interests.
 type InterestService struct {
 	c *fb.Client
+	v Version
 }
 
 // Search returns a list of InterestTargetings.
@@ -20,7 +21,7 @@ func (is *InterestService) Search(ctx context.Context, query string, limit int)
 	}
 
 	res := []InterestTargeting{}
-	err := is.c.GetList(ctx, fb.NewRoute(Version, "/search").Type("adinterest").Q(query).Limit(limit).Fields("id", "name", "audience_size_upper_bound", "path", "description", "topic").String(), &res)
+	err := is.c.GetList(ctx, fb.NewRoute(is.v.Name, "/search").Type("adinterest").Q(query).Limit(limit).Fields("id", "name", "audience_size_upper_bound", "path", "description", "topic").String(), &res)
 	if err != nil {
 		return nil, err
 	}
@@ -28,17 +29,28 @@ func (is *InterestService) Search(ctx context.Context, query string, limit int)
 	return res, nil
 }
 
-// TargetingSearch searches for a targeting. limitType filters results by targeting category
-// (e.g. "interests", "behaviors", "keywords", etc.).
+// TargetingSearch searches for a targeting. The optional limitType is passed to TargetingSearchWithLimitType,
+// it is variadic so calls written against the v22 package keep compiling.
+func (is *InterestService) TargetingSearch(ctx context.Context, act, query string, limitType ...string) ([]InterestTargeting, error) {
+	lt := ""
+	if len(limitType) > 0 {
+		lt = limitType[0]
+	}
+
+	return is.TargetingSearchWithLimitType(ctx, act, query, lt)
+}
+
+// TargetingSearchWithLimitType searches for a targeting. limitType filters results by targeting category
+// (e.g. "interests", "behaviors", "keywords", etc.), empty searches all categories.
 // https://developers.facebook.com/docs/marketing-api/reference/ad-account/targetingsearch/
-func (is *InterestService) TargetingSearch(ctx context.Context, act, query, limitType string) ([]InterestTargeting, error) {
+func (is *InterestService) TargetingSearchWithLimitType(ctx context.Context, act, query, limitType string) ([]InterestTargeting, error) {
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return []InterestTargeting{}, nil
 	}
 
 	res := []InterestTargeting{}
-	err := is.c.GetList(ctx, fb.NewRoute(Version, "/act_%s/targetingsearch", act).LimitType(limitType).Q(query).Fields("id", "name", "audience_size_upper_bound", "type", "path").String(), &res)
+	err := is.c.GetList(ctx, fb.NewRoute(is.v.Name, "/act_%s/targetingsearch", act).LimitType(limitType).Q(query).Fields("id", "name", "audience_size_upper_bound", "type", "path").String(), &res)
 	if err != nil {
 		return nil, err
 	}
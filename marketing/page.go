@@ -1,8 +1,10 @@
-package v22
+package marketing
 
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
 )
@@ -10,6 +12,7 @@ import (
 // PageService contains all methods for working on pages.
 type PageService struct {
 	c *fb.Client
+	v Version
 }
 
 // SetPageAccessToken tries to retrieve the access token for a facebook page and includes it in the passed context so the fb.Client can use it for making requests.
@@ -17,7 +20,7 @@ func (ps *PageService) SetPageAccessToken(ctx context.Context, pageID string) (c
 	tc := struct {
 		AccessToken string `json:"access_token"`
 	}{}
-	err := ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", pageID).Fields("access_token").String(), &tc)
+	err := ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s", pageID).Fields("access_token").String(), &tc)
 	if err != nil {
 		return ctx, err
 	} else if tc.AccessToken == "" {
@@ -37,7 +40,7 @@ func (ps *PageService) GetInstagramBusinessAccount(ctx context.Context, pageID s
 	fpiga := struct {
 		InstagramBusinessAccount InstagramUser `json:"instagram_business_account"`
 	}{}
-	err = ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", pageID).Fields("instagram_business_account{id,username}").String(), &fpiga)
+	err = ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s", pageID).Fields("instagram_business_account{id,username}").String(), &fpiga)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +59,7 @@ func (ps *PageService) GetInstagramBusinessAccount(ctx context.Context, pageID s
 // GetClientPages returns all client pages.
 func (ps *PageService) GetClientPages(ctx context.Context, businessID string) ([]Page, error) {
 	res := []Page{}
-	route := fb.NewRoute(Version, "/%s/client_pages", businessID).Limit(1000).Fields(pageFields...)
+	route := fb.NewRoute(ps.v.Name, "/%s/client_pages", businessID).Limit(1000).Fields(pageFields...)
 	err := ps.c.GetList(ctx, route.String(), &res)
 	if err != nil {
 		return nil, err
@@ -68,7 +71,19 @@ func (ps *PageService) GetClientPages(ctx context.Context, businessID string) ([
 // GetOwnedPages returns all owned pages.
 func (ps *PageService) GetOwnedPages(ctx context.Context, businessID string) ([]Page, error) {
 	res := []Page{}
-	route := fb.NewRoute(Version, "/%s/owned_pages", businessID).Limit(1000).Fields(pageFields...)
+	route := fb.NewRoute(ps.v.Name, "/%s/owned_pages", businessID).Limit(1000).Fields(pageFields...)
+	err := ps.c.GetList(ctx, route.String(), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetPromotePages returns the pages an ad account can promote, together with their instagram business accounts.
+func (ps *PageService) GetPromotePages(ctx context.Context, act string) ([]Page, error) {
+	res := []Page{}
+	route := fb.NewRoute(ps.v.Name, "/act_%s/promote_pages", act).Limit(1000).Fields(append(pageFields, "instagram_business_account{id,username}")...)
 	err := ps.c.GetList(ctx, route.String(), &res)
 	if err != nil {
 		return nil, err
@@ -83,7 +98,7 @@ func (ps *PageService) GetInstagramUsers(ctx context.Context, businessID string)
 		ID string `json:"id"`
 	}
 	var pages []Page
-	pageRoute := fb.NewRoute(Version, "/%s/owned_pages", businessID).Fields("id").Limit(100)
+	pageRoute := fb.NewRoute(ps.v.Name, "/%s/owned_pages", businessID).Fields("id").Limit(100)
 	if err := ps.c.GetList(ctx, pageRoute.String(), &pages); err != nil {
 		return nil, err
 	}
@@ -93,7 +108,7 @@ func (ps *PageService) GetInstagramUsers(ctx context.Context, businessID string)
 		var wrapper struct {
 			InstagramAccount *InstagramUser `json:"instagram_business_account"`
 		}
-		igRoute := fb.NewRoute(Version, "/%s", page.ID).Fields("instagram_business_account{id,username}")
+		igRoute := fb.NewRoute(ps.v.Name, "/%s", page.ID).Fields("instagram_business_account{id,username}")
 		if err := ps.c.GetJSON(ctx, igRoute.String(), &wrapper); err != nil {
 			continue
 		}
@@ -110,7 +125,7 @@ func (ps *PageService) ListIGUsers(ctx context.Context) ([]InstagramUser, error)
 		ID string `json:"id"`
 	}
 	var pages []Page
-	pageRoute := fb.NewRoute(Version, "/me/accounts").Fields("id").Limit(100)
+	pageRoute := fb.NewRoute(ps.v.Name, "/me/accounts").Fields("id").Limit(100)
 	if err := ps.c.GetList(ctx, pageRoute.String(), &pages); err != nil {
 		return nil, err
 	}
@@ -121,7 +136,7 @@ func (ps *PageService) ListIGUsers(ctx context.Context) ([]InstagramUser, error)
 		var wrapper struct {
 			InstagramAccount *InstagramUser `json:"instagram_business_account"`
 		}
-		igRoute := fb.NewRoute(Version, "/%s", page.ID).Fields("instagram_business_account{id,username}")
+		igRoute := fb.NewRoute(ps.v.Name, "/%s", page.ID).Fields("instagram_business_account{id,username}")
 		if err := ps.c.GetJSON(ctx, igRoute.String(), &wrapper); err != nil {
 			continue
 		}
@@ -136,7 +151,7 @@ func (ps *PageService) ListIGUsers(ctx context.Context) ([]InstagramUser, error)
 // Get returns a single page.
 func (ps *PageService) Get(ctx context.Context, id string) (*Page, error) {
 	res := &Page{}
-	route := fb.NewRoute(Version, "/%s", id).Fields(pageFields...)
+	route := fb.NewRoute(ps.v.Name, "/%s", id).Fields(pageFields...)
 	err := ps.c.GetJSON(ctx, route.String(), res)
 	if err != nil {
 		if fb.IsNotFound(err) {
@@ -152,7 +167,7 @@ func (ps *PageService) Get(ctx context.Context, id string) (*Page, error) {
 // GetInstagramUser returns a single instagram user.
 func (ps *PageService) GetInstagramUser(ctx context.Context, id string) (*InstagramUser, error) {
 	res := &InstagramUser{}
-	route := fb.NewRoute(Version, "/%s", id).Fields(instagramUserFields...)
+	route := fb.NewRoute(ps.v.Name, "/%s", id).Fields(instagramUserFields...)
 	err := ps.c.GetJSON(ctx, route.String(), res)
 	if err != nil {
 		return nil, err
@@ -161,6 +176,48 @@ func (ps *PageService) GetInstagramUser(ctx context.Context, id string) (*Instag
 	return res, nil
 }
 
+// SubscribeApp subscribes the app to the given webhook fields of a page, e.g. "leadgen" or "feed".
+// Subscribing again replaces the previously subscribed fields.
+func (ps *PageService) SubscribeApp(ctx context.Context, pageID string, fields ...string) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("cannot subscribe to page '%s' without fields", pageID)
+	}
+	ctx, err := ps.SetPageAccessToken(ctx, pageID)
+	if err != nil {
+		return err
+	}
+
+	return ps.c.PostValues(ctx, fb.NewRoute(ps.v.Name, "/%s/subscribed_apps", pageID).String(), url.Values{
+		"subscribed_fields": {strings.Join(fields, ",")},
+	})
+}
+
+// UnsubscribeApp removes all webhook subscriptions of the app from a page.
+func (ps *PageService) UnsubscribeApp(ctx context.Context, pageID string) error {
+	ctx, err := ps.SetPageAccessToken(ctx, pageID)
+	if err != nil {
+		return err
+	}
+
+	return ps.c.Delete(ctx, fb.NewRoute(ps.v.Name, "/%s/subscribed_apps", pageID).String())
+}
+
+// ListSubscribedApps returns the apps subscribed to a page and their webhook fields.
+func (ps *PageService) ListSubscribedApps(ctx context.Context, pageID string) ([]SubscribedApp, error) {
+	ctx, err := ps.SetPageAccessToken(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []SubscribedApp{}
+	err = ps.c.GetList(ctx, fb.NewRoute(ps.v.Name, "/%s/subscribed_apps", pageID).String(), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 var (
 	pageFields          = []string{"id", "global_brand_page_name"}
 	instagramUserFields = []string{"id", "username"}
@@ -170,6 +227,8 @@ var (
 type Page struct {
 	ID                  string `json:"id"`
 	GlobalBrandPageName string `json:"global_brand_page_name"`
+	// InstagramBusinessAccount is only set by GetPromotePages.
+	InstagramBusinessAccount *InstagramUser `json:"instagram_business_account,omitempty"`
 }
 
 // InstagramActor represents an instagram actor.
@@ -177,3 +236,10 @@ type InstagramUser struct {
 	ID       string `json:"id"`
 	Username string `json:"username"`
 }
+
+// SubscribedApp is an app receiving webhooks for a page.
+type SubscribedApp struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	SubscribedFields []string `json:"subscribed_fields"`
+}
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -20,6 +20,7 @@ const (
 // PostService works on posts.
 type PostService struct {
 	c *fb.Client
+	v Version
 	*fb.StatsContainer
 }
 
@@ -33,7 +34,7 @@ type postID struct {
 // Get postID from image/video/carousel post
 func (ps *PostService) GetPostID(ctx context.Context, pID string) (*string, error) {
 	res := &postID{}
-	err := ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", pID).Fields("from", "id").String(), res)
+	err := ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s", pID).Fields("from", "id").String(), res)
 	if err != nil {
 		if fb.IsNotFound(err) {
 			return nil, nil
@@ -51,7 +52,7 @@ func (ps *PostService) SetPageAccessToken(ctx context.Context, pageID string) (c
 	tc := struct {
 		AccessToken string `json:"access_token"`
 	}{}
-	err := ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", pageID).Fields("access_token").String(), &tc)
+	err := ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s", pageID).Fields("access_token").String(), &tc)
 	if err != nil {
 		return ctx, err
 	} else if tc.AccessToken == "" {
@@ -64,7 +65,7 @@ func (ps *PostService) SetPageAccessToken(ctx context.Context, pageID string) (c
 // Get returns a single post.
 func (ps *PostService) Get(ctx context.Context, id string) (*Post, error) {
 	res := &Post{}
-	err := ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Fields(postFields...).String(), res)
+	err := ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s", id).Fields(postFields...).String(), res)
 	if err != nil {
 		if fb.IsNotFound(err) {
 			return nil, nil
@@ -88,7 +89,7 @@ func (ps *PostService) getPostAttachments(ctx context.Context, post *Post) error
 		return nil
 	}
 	pAs := &StoryAttachments{}
-	err := ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s/attachments", post.ID).Fields(postAttachmentsFields...).String(), pAs)
+	err := ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s/attachments", post.ID).Fields(postAttachmentsFields...).String(), pAs)
 	if err != nil {
 		if fb.IsNotFound(err) {
 			return nil
@@ -104,7 +105,9 @@ func (ps *PostService) getPostAttachments(ctx context.Context, post *Post) error
 	}
 	sat := strings.TrimSpace(pA.StoryAttachmentType)
 	mt := strings.TrimSpace(pA.MediaType)
-	if mt != "" {
+	if mt == "link" && ps.v.LinkPostsAsStatus {
+		post.Type = "status"
+	} else if mt != "" {
 		post.Type = mt
 	} else if sat != "" {
 		post.Type = sat
@@ -142,7 +145,7 @@ func (ps *PostService) GetReactions(ctx context.Context, postID string) (Reactio
 	m := Reactions{}
 	for _, r := range reactions {
 		rc := fb.SummaryContainer{}
-		err := ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s/reactions", postID).Summary("total_count").Limit(0).Type(r).String(), &rc)
+		err := ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s/reactions", postID).Summary("total_count").Limit(0).Type(r).String(), &rc)
 		if err != nil {
 			return nil, err
 		} else if rc.Summary.TotalCount == 0 {
@@ -158,7 +161,7 @@ func (ps *PostService) GetReactions(ctx context.Context, postID string) (Reactio
 // CountComments returns the total amount of parent comments.
 func (ps *PostService) CountComments(ctx context.Context, postID string) (uint64, error) {
 	sc := &fb.SummaryContainer{}
-	err := ps.c.GetJSON(ctx, fb.NewRoute(Version, "/%s/comments", postID).Limit(0).Summary("1").String(), sc)
+	err := ps.c.GetJSON(ctx, fb.NewRoute(ps.v.Name, "/%s/comments", postID).Limit(0).Summary("1").String(), sc)
 
 	return sc.Summary.TotalCount, err
 }
@@ -272,7 +275,7 @@ func (ps *PostService) fetchPostAttachedObjectIDs(ctx context.Context, postIDs [
 // Filters may be "stream" or "toplevel".
 func (ps *PostService) ListComments(postID, filter string) *CommentListCall {
 	return &CommentListCall{
-		RouteBuilder:   fb.NewRoute(Version, "/%s/comments", postID).Fields("message", "message_tags", "parent", "from", "created_time").Limit(100).Order("chronological").Filter(filter),
+		RouteBuilder:   fb.NewRoute(ps.v.Name, "/%s/comments", postID).Fields("message", "message_tags", "parent", "from", "created_time").Limit(100).Order("chronological").Filter(filter),
 		c:              ps.c,
 		id:             postID,
 		StatsContainer: ps.StatsContainer,
@@ -342,7 +345,7 @@ func (ps *PostService) ListOfPage(pageID string, fields []string) *PostListCall
 	}
 
 	return &PostListCall{
-		RouteBuilder: fb.NewRoute(Version, "/%s/posts", pageID).Limit(postListLimit).Fields(fields...),
+		RouteBuilder: fb.NewRoute(ps.v.Name, "/%s/posts", pageID).Limit(postListLimit).Fields(fields...),
 		c:            ps.c,
 		pageID:       pageID,
 		ps:           ps,
@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -100,7 +100,7 @@ func TestCountCommentsByPostIDsBatchesAndResolvesAttachedObjects(t *testing.T) {
 			Request:    request,
 		}, nil
 	})}
-	service := &PostService{c: client}
+	service := &PostService{c: client, v: Version{Name: "v24.0"}}
 
 	got, err := service.CountCommentsByPostIDs(context.Background(), postIDs)
 	if err != nil {
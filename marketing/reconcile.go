@@ -0,0 +1,713 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// Actions of a PlanChange.
+const (
+	PlanCreate = "create"
+	PlanUpdate = "update"
+	// PlanReplace creates a new creative, the ads using it are updated. The old creative is only deleted
+	// with PruneDelete, otherwise it is kept.
+	PlanReplace = "replace"
+	PlanPause   = "pause"
+	PlanDelete  = "delete"
+)
+
+// Prune modes of PlanOptions, they apply to the objects of the state that are not desired anymore.
+const (
+	PruneNone   = ""
+	PrunePause  = "pause"
+	PruneDelete = "delete"
+)
+
+// ObjectTypeCreative is the type of creatives in a Plan.
+const ObjectTypeCreative = "creative"
+
+// jsonTimeFormat is the format fb.Time is encoded with.
+const jsonTimeFormat = "2006-01-02T15:04:05-0700"
+
+// reconcileIgnoredFields are read only or set from the keys of the desired objects.
+var reconcileIgnoredFields = map[string]bool{
+	"id": true, "account_id": true, "created_time": true, "updated_time": true,
+	"effective_status": true, "configured_status": true, "budget_remaining": true,
+	"campaign_id": true, "campaign": true, "adset_id": true, "adset": true,
+	"creative": true, "adcreatives": true,
+}
+
+// DesiredCampaign is a campaign identified by a stable external key.
+type DesiredCampaign struct {
+	Key      string
+	Campaign Campaign
+}
+
+// DesiredAdset is an adset of the campaign with CampaignKey.
+type DesiredAdset struct {
+	Key         string
+	CampaignKey string
+	Adset       Adset
+}
+
+// DesiredAd is an ad of the adset with AdsetKey using the creative with CreativeKey.
+type DesiredAd struct {
+	Key         string
+	AdsetKey    string
+	CreativeKey string
+	Ad          Ad
+}
+
+// DesiredCreative is a creative identified by a stable external key.
+type DesiredCreative struct {
+	Key      string
+	Creative AdCreative
+}
+
+// DesiredState is the desired structure of an account. Only the fields set to non zero values
+// are managed, ids and parent ids are taken from the ReconcileState and the keys.
+type DesiredState struct {
+	Campaigns []DesiredCampaign
+	Adsets    []DesiredAdset
+	Ads       []DesiredAd
+	Creatives []DesiredCreative
+}
+
+// ReconcileState maps the keys of the managed objects to their ids, it should be persisted between applies.
+type ReconcileState struct {
+	Campaigns map[string]string `json:"campaigns"`
+	Adsets    map[string]string `json:"adsets"`
+	Ads       map[string]string `json:"ads"`
+	Creatives map[string]string `json:"creatives"`
+}
+
+// NewReconcileState returns an empty state.
+func NewReconcileState() *ReconcileState {
+	return &ReconcileState{
+		Campaigns: map[string]string{},
+		Adsets:    map[string]string{},
+		Ads:       map[string]string{},
+		Creatives: map[string]string{},
+	}
+}
+
+// ids returns the ids of the object type.
+func (rs *ReconcileState) ids(objectType string) map[string]string {
+	switch objectType {
+	case ObjectTypeCampaign:
+		return rs.Campaigns
+	case ObjectTypeAdset:
+		return rs.Adsets
+	case ObjectTypeAd:
+		return rs.Ads
+	default:
+		return rs.Creatives
+	}
+}
+
+func (rs *ReconcileState) copy() *ReconcileState {
+	res := NewReconcileState()
+	for _, t := range []string{ObjectTypeCampaign, ObjectTypeAdset, ObjectTypeAd, ObjectTypeCreative} {
+		for k, id := range rs.ids(t) {
+			res.ids(t)[k] = id
+		}
+	}
+
+	return res
+}
+
+// PlanOptions configures Plan.
+type PlanOptions struct {
+	// Prune is one of the Prune constants. Creatives are only pruned by PruneDelete, which also
+	// deletes the creatives replaced by the plan after the ads using them were updated.
+	Prune string
+}
+
+// FieldChange is the change of a single field.
+type FieldChange struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+// PlanChange is a single change of a Plan. ID is empty for creates.
+type PlanChange struct {
+	Action string
+	Type   string
+	Key    string
+	ID     string
+	Fields []FieldChange
+
+	desired interface{}
+}
+
+// Plan contains the changes needed to reach the desired state, in the order they are applied.
+type Plan struct {
+	AccountID string
+	Changes   []PlanChange
+
+	state *ReconcileState
+}
+
+// Empty returns true if the live state matches the desired state.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns a human readable summary of the plan.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	sb := &strings.Builder{}
+	counts := map[string]int{}
+	for _, c := range p.Changes {
+		counts[c.Action]++
+		symbol := map[string]string{PlanCreate: "+", PlanUpdate: "~", PlanReplace: "+/-", PlanPause: "||", PlanDelete: "-"}[c.Action]
+		fmt.Fprintf(sb, "%s %s %s %q", symbol, c.Action, c.Type, c.Key)
+		if c.ID != "" {
+			fmt.Fprintf(sb, " (%s)", c.ID)
+		}
+		sb.WriteString("\n")
+		for _, f := range c.Fields {
+			fmt.Fprintf(sb, "    %s: %s => %s\n", f.Field, planValue(f.Old), planValue(f.New))
+		}
+	}
+	fmt.Fprintf(sb, "Plan: %d to create, %d to update, %d to replace, %d to pause, %d to delete.\n",
+		counts[PlanCreate], counts[PlanUpdate], counts[PlanReplace], counts[PlanPause], counts[PlanDelete])
+
+	return sb.String()
+}
+
+func planValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
+
+// Plan compares the desired state with the live objects of the account in the state and returns the changes
+// to be applied by Apply. Objects without an id in the state or that don't exist anymore are created.
+func (s *Service) Plan(ctx context.Context, act string, desired DesiredState, state *ReconcileState, opts PlanOptions) (*Plan, error) {
+	if state == nil {
+		state = NewReconcileState()
+	}
+	state = state.copy()
+	err := desired.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.LoadAccountSnapshot(ctx, act, desired.snapshotOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{AccountID: act, state: state}
+	replacedCreatives := map[string]bool{}
+	for i := range desired.Creatives {
+		d := &desired.Creatives[i]
+		id := state.Creatives[d.Key]
+		var live *AdCreative
+		if n := snapshot.Creative(id); n != nil {
+			live = n.Creative
+		} else if id != "" {
+			live, err = (&AdCreativeService{c: s.Client, v: s.v}).Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if live == nil {
+			replacedCreatives[d.Key] = true
+			p.add(PlanCreate, ObjectTypeCreative, d.Key, "", nil, d)
+			continue
+		}
+		changes, err := diffFields(d.Creative, live)
+		if err != nil {
+			return nil, err
+		} else if len(changes) > 0 {
+			replacedCreatives[d.Key] = true
+			p.add(PlanReplace, ObjectTypeCreative, d.Key, id, changes, d)
+		}
+	}
+
+	for i := range desired.Campaigns {
+		d := &desired.Campaigns[i]
+		n := snapshot.Campaign(state.Campaigns[d.Key])
+		if n == nil {
+			p.add(PlanCreate, ObjectTypeCampaign, d.Key, "", nil, d)
+			continue
+		}
+		changes, err := diffFields(d.Campaign, n.Campaign)
+		if err != nil {
+			return nil, err
+		} else if len(changes) > 0 {
+			p.add(PlanUpdate, ObjectTypeCampaign, d.Key, n.Campaign.ID, changes, d)
+		}
+	}
+
+	for i := range desired.Adsets {
+		d := &desired.Adsets[i]
+		n := snapshot.Adset(state.Adsets[d.Key])
+		if n == nil {
+			p.add(PlanCreate, ObjectTypeAdset, d.Key, "", nil, d)
+			continue
+		} else if n.Adset.CampaignID != state.Campaigns[d.CampaignKey] {
+			return nil, fmt.Errorf("cannot move adset %s to campaign %s", d.Key, d.CampaignKey)
+		}
+		changes, err := diffFields(d.Adset, n.Adset)
+		if err != nil {
+			return nil, err
+		} else if len(changes) > 0 {
+			p.add(PlanUpdate, ObjectTypeAdset, d.Key, n.Adset.ID, changes, d)
+		}
+	}
+
+	for i := range desired.Ads {
+		d := &desired.Ads[i]
+		n := snapshot.Ad(state.Ads[d.Key])
+		if n == nil {
+			p.add(PlanCreate, ObjectTypeAd, d.Key, "", nil, d)
+			continue
+		} else if adParentID(n.Ad) != state.Adsets[d.AdsetKey] {
+			return nil, fmt.Errorf("cannot move ad %s to adset %s", d.Key, d.AdsetKey)
+		}
+		changes, err := diffFields(d.Ad, n.Ad)
+		if err != nil {
+			return nil, err
+		}
+		liveCreative := ""
+		if n.Ad.Creative != nil {
+			liveCreative = n.Ad.Creative.ID
+		}
+		if replacedCreatives[d.CreativeKey] {
+			changes = append(changes, FieldChange{Field: "creative", Old: liveCreative, New: "(new creative " + d.CreativeKey + ")"})
+		} else if id := state.Creatives[d.CreativeKey]; id != liveCreative {
+			changes = append(changes, FieldChange{Field: "creative", Old: liveCreative, New: id})
+		}
+		if len(changes) > 0 {
+			p.add(PlanUpdate, ObjectTypeAd, d.Key, n.Ad.ID, changes, d)
+		}
+	}
+
+	if opts.Prune != PruneNone {
+		err = p.prune(snapshot, desired, opts.Prune)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (p *Plan) add(action, objectType, key, id string, fields []FieldChange, desired interface{}) {
+	p.Changes = append(p.Changes, PlanChange{
+		Action:  action,
+		Type:    objectType,
+		Key:     key,
+		ID:      id,
+		Fields:  fields,
+		desired: desired,
+	})
+}
+
+// prune adds pauses or deletes of the objects in the state that are not desired, children first.
+func (p *Plan) prune(snapshot *AccountSnapshot, desired DesiredState, mode string) error {
+	if mode != PrunePause && mode != PruneDelete {
+		return fmt.Errorf("unknown prune mode '%s'", mode)
+	}
+	keys := map[string]map[string]bool{}
+	for _, d := range desired.Campaigns {
+		keys[ObjectTypeCampaign] = appendKey(keys[ObjectTypeCampaign], d.Key)
+	}
+	for _, d := range desired.Adsets {
+		keys[ObjectTypeAdset] = appendKey(keys[ObjectTypeAdset], d.Key)
+	}
+	for _, d := range desired.Ads {
+		keys[ObjectTypeAd] = appendKey(keys[ObjectTypeAd], d.Key)
+	}
+	for _, d := range desired.Creatives {
+		keys[ObjectTypeCreative] = appendKey(keys[ObjectTypeCreative], d.Key)
+	}
+
+	for _, t := range []string{ObjectTypeAd, ObjectTypeAdset, ObjectTypeCampaign} {
+		ids := p.state.ids(t)
+		for _, k := range stateKeys(ids) {
+			if keys[t][k] {
+				continue
+			}
+			status := ""
+			switch n := snapshotObject(snapshot, t, ids[k]); {
+			case n == nil:
+				continue
+			case t == ObjectTypeCampaign:
+				status = n.(*CampaignNode).Campaign.Status
+			case t == ObjectTypeAdset:
+				status = n.(*AdsetNode).Adset.Status
+			default:
+				status = n.(*AdNode).Ad.Status
+			}
+			if mode == PruneDelete {
+				p.add(PlanDelete, t, k, ids[k], nil, nil)
+			} else if status != StatusPaused {
+				p.add(PlanPause, t, k, ids[k], []FieldChange{{Field: "status", Old: status, New: StatusPaused}}, nil)
+			}
+		}
+	}
+	if mode == PruneDelete {
+		var replaced []PlanChange
+		for _, c := range p.Changes {
+			if c.Action == PlanReplace {
+				replaced = append(replaced, c)
+			}
+		}
+		for _, c := range replaced {
+			p.add(PlanDelete, ObjectTypeCreative, c.Key, c.ID, nil, nil)
+		}
+		for _, k := range stateKeys(p.state.Creatives) {
+			if !keys[ObjectTypeCreative][k] {
+				p.add(PlanDelete, ObjectTypeCreative, k, p.state.Creatives[k], nil, nil)
+			}
+		}
+	}
+
+	return nil
+}
+
+func appendKey(keys map[string]bool, key string) map[string]bool {
+	if keys == nil {
+		keys = map[string]bool{}
+	}
+	keys[key] = true
+
+	return keys
+}
+
+// snapshotObject returns the node of the object or nil.
+func snapshotObject(s *AccountSnapshot, objectType, id string) interface{} {
+	switch objectType {
+	case ObjectTypeCampaign:
+		if n := s.Campaign(id); n != nil {
+			return n
+		}
+	case ObjectTypeAdset:
+		if n := s.Adset(id); n != nil {
+			return n
+		}
+	case ObjectTypeAd:
+		if n := s.Ad(id); n != nil {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// Apply applies the changes in order and returns the updated state.
+// If a change fails, the state of the changes applied so far is returned together with the error.
+func (s *Service) Apply(ctx context.Context, p *Plan) (*ReconcileState, error) {
+	state := p.state.copy()
+	for _, c := range p.Changes {
+		err := s.applyChange(ctx, p.AccountID, state, c)
+		if err != nil {
+			return state, fmt.Errorf("%s %s %s: %w", c.Action, c.Type, c.Key, err)
+		}
+	}
+
+	return state, nil
+}
+
+func (s *Service) applyChange(ctx context.Context, act string, state *ReconcileState, c PlanChange) error {
+	switch c.Action {
+	case PlanPause:
+		return setStatus(ctx, s.Client, s.v, c.ID, StatusPaused)
+	case PlanDelete:
+		err := s.Client.Delete(ctx, fb.NewRoute(s.v.Name, "/%s", c.ID).String())
+		if err != nil {
+			return err
+		}
+		// the key of a replaced creative already has the id of the new one
+		if state.ids(c.Type)[c.Key] == c.ID {
+			delete(state.ids(c.Type), c.Key)
+		}
+
+		return nil
+	case PlanUpdate:
+		fields := map[string]interface{}{}
+		for _, f := range c.Fields {
+			fields[f.Field] = f.New
+		}
+		if d, ok := c.desired.(*DesiredAd); ok && fields["creative"] != nil {
+			fields["creative"] = map[string]string{"creative_id": state.Creatives[d.CreativeKey]}
+		}
+
+		return updateFields(ctx, s.Client, s.v, c.ID, fields)
+	}
+
+	var (
+		id  string
+		err error
+	)
+	switch d := c.desired.(type) {
+	case *DesiredCreative:
+		cr := d.Creative
+		cr.AccountID = act
+		id, _, err = (&AdCreativeService{c: s.Client, v: s.v}).Create(ctx, cr)
+	case *DesiredCampaign:
+		cp := d.Campaign
+		cp.AccountID = act
+		id, err = (&CampaignService{s.Client, s.v}).Create(ctx, cp)
+	case *DesiredAdset:
+		a := d.Adset
+		a.AccountID, a.CampaignID = act, state.Campaigns[d.CampaignKey]
+		id, _, err = (&AdsetService{s.Client, s.v}).Create(ctx, a)
+	case *DesiredAd:
+		a := d.Ad
+		a.AccountID, a.AdsetID = act, state.Adsets[d.AdsetKey]
+		a.Creative = &AdCreative{CreativeID: state.Creatives[d.CreativeKey]}
+		id, err = (&AdService{s.Client, s.v}).Create(ctx, a)
+	default:
+		return fmt.Errorf("unknown change %s", c.Action)
+	}
+	if err != nil {
+		return err
+	}
+	state.ids(c.Type)[c.Key] = id
+
+	return nil
+}
+
+// updateFields updates only the given fields of an object.
+func updateFields(ctx context.Context, c *fb.Client, v Version, id string, fields map[string]interface{}) error {
+	res := &fb.MinimalResponse{}
+	err := c.PostJSON(ctx, fb.NewRoute(v.Name, "/%s", id).String(), fields, res)
+	if err != nil {
+		return err
+	} else if err = res.GetError(); err != nil {
+		return err
+	} else if !res.Success && res.ID == "" {
+		return fmt.Errorf("updating %s failed", id)
+	}
+
+	return nil
+}
+
+// validate checks that the keys are unique and the references exist.
+func (ds DesiredState) validate() error {
+	keys := map[string]map[string]bool{}
+	add := func(t, k string) error {
+		if k == "" {
+			return fmt.Errorf("missing key of %s", t)
+		} else if keys[t][k] {
+			return fmt.Errorf("duplicate %s key %s", t, k)
+		}
+		keys[t] = appendKey(keys[t], k)
+
+		return nil
+	}
+	for _, d := range ds.Creatives {
+		if err := add(ObjectTypeCreative, d.Key); err != nil {
+			return err
+		}
+	}
+	for _, d := range ds.Campaigns {
+		if err := add(ObjectTypeCampaign, d.Key); err != nil {
+			return err
+		}
+	}
+	for _, d := range ds.Adsets {
+		if err := add(ObjectTypeAdset, d.Key); err != nil {
+			return err
+		} else if !keys[ObjectTypeCampaign][d.CampaignKey] {
+			return fmt.Errorf("adset %s references unknown campaign %s", d.Key, d.CampaignKey)
+		}
+	}
+	for _, d := range ds.Ads {
+		if err := add(ObjectTypeAd, d.Key); err != nil {
+			return err
+		} else if !keys[ObjectTypeAdset][d.AdsetKey] {
+			return fmt.Errorf("ad %s references unknown adset %s", d.Key, d.AdsetKey)
+		} else if !keys[ObjectTypeCreative][d.CreativeKey] {
+			return fmt.Errorf("ad %s references unknown creative %s", d.Key, d.CreativeKey)
+		}
+	}
+
+	return nil
+}
+
+// snapshotOptions requests the managed fields of the desired objects.
+func (ds DesiredState) snapshotOptions() SnapshotOptions {
+	opts := SnapshotOptions{}
+	for _, d := range ds.Campaigns {
+		opts.CampaignFields = managedFields(opts.CampaignFields, d.Campaign)
+	}
+	for _, d := range ds.Adsets {
+		opts.AdsetFields = managedFields(opts.AdsetFields, d.Adset)
+	}
+	for _, d := range ds.Ads {
+		opts.AdFields = managedFields(opts.AdFields, d.Ad)
+	}
+	for _, d := range ds.Creatives {
+		opts.CreativeFields = managedFields(opts.CreativeFields, d.Creative)
+	}
+	opts.CampaignFields = withFields(opts.CampaignFields, "id", "name", "status")
+	opts.AdsetFields = withFields(opts.AdsetFields, "id", "name", "status")
+	opts.AdFields = withFields(opts.AdFields, "id", "name", "status")
+	opts.CreativeFields = withFields(opts.CreativeFields, "id", "name")
+
+	return opts
+}
+
+// managedFields adds the managed fields of the object to fields.
+func managedFields(fields []string, o interface{}) []string {
+	m, err := managedValues(o)
+	if err != nil {
+		return fields
+	}
+
+	return withFields(fields, valueKeys(m)...)
+}
+
+// managedValues returns the non zero fields of the object that are not ignored.
+func managedValues(o interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range m {
+		if reconcileIgnoredFields[k] || isZeroJSON(v) {
+			delete(m, k)
+		}
+	}
+
+	return m, nil
+}
+
+func isZeroJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == "" || strings.HasPrefix(v, "0001-01-01T00:00:00")
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// diffFields returns the changes of the managed fields of desired compared to live.
+// Nested objects only differ in the keys set in desired, as the API adds defaults to them.
+func diffFields(desired, live interface{}) ([]FieldChange, error) {
+	want, err := managedValues(desired)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(live)
+	if err != nil {
+		return nil, err
+	}
+	have := map[string]interface{}{}
+	err = json.Unmarshal(b, &have)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []FieldChange{}
+	for _, k := range valueKeys(want) {
+		if !equalJSON(want[k], have[k]) {
+			changes = append(changes, FieldChange{Field: k, Old: have[k], New: want[k]})
+		}
+	}
+
+	return changes, nil
+}
+
+// equalJSON compares the decoded JSON values of a desired and a live field. Times are compared
+// as instants because the API returns them in the timezone of the account.
+func equalJSON(want, have interface{}) bool {
+	switch w := want.(type) {
+	case string:
+		h, ok := have.(string)
+		if !ok {
+			return false
+		}
+		wt, err := time.Parse(jsonTimeFormat, w)
+		if err != nil {
+			return w == h
+		}
+		ht, err := time.Parse(jsonTimeFormat, h)
+
+		return err == nil && wt.Equal(ht)
+	case map[string]interface{}:
+		h, ok := have.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range w {
+			if !equalJSON(v, h[k]) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		h, ok := have.([]interface{})
+		if !ok || len(w) != len(h) {
+			return false
+		}
+		for i := range w {
+			if !equalJSON(w[i], h[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(want, have)
+}
+
+func stateKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func valueKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
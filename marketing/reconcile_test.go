@@ -0,0 +1,165 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestPlanApply(t *testing.T) {
+	live, creatives := `{"data":[]}`, `{"data":[]}`
+	posts := []string{}
+	created := map[string]int{}
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: reconcileRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		body := ""
+		switch {
+		case request.Method == http.MethodGet && request.URL.Path == "/v24.0/act_1/campaigns":
+			if fields := request.URL.Query().Get("fields"); !strings.Contains(fields, "daily_budget") {
+				t.Fatalf("managed fields not requested: %s", fields)
+			}
+			body = live
+		case request.Method == http.MethodGet && request.URL.Path == "/v24.0/act_1/adcreatives":
+			body = creatives
+		case request.Method == http.MethodPost:
+			params := map[string]interface{}{}
+			if err := json.NewDecoder(request.Body).Decode(&params); err != nil {
+				t.Fatal(err)
+			}
+			b, _ := json.Marshal(params)
+			path := strings.TrimPrefix(request.URL.Path, "/v24.0/")
+			posts = append(posts, path+" "+string(b))
+			body = `{"success":true}`
+			if edge := strings.TrimPrefix(path, "act_1/"); edge != path {
+				created[edge]++
+				body = fmt.Sprintf(`{"id":"%s-%d"}`, edge, created[edge])
+			}
+		case request.Method == http.MethodDelete:
+			posts = append(posts, "DELETE "+strings.TrimPrefix(request.URL.Path, "/v24.0/"))
+			body = `{"success":true}`
+		default:
+			t.Fatalf("unexpected request %s %s", request.Method, request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &Service{Client: client, v: Version{Name: "v24.0"}}
+	ctx := context.Background()
+	desired := DesiredState{
+		Campaigns: []DesiredCampaign{{Key: "launch", Campaign: Campaign{Name: "Launch", Objective: "OUTCOME_TRAFFIC", Status: "PAUSED"}}},
+		Adsets: []DesiredAdset{{Key: "us", CampaignKey: "launch", Adset: Adset{
+			Name: "US", DailyBudget: 2000, StartTime: fb.Time(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)),
+			Targeting: &Targeting{AgeMin: 18, GeoLocations: &GeoLocations{Countries: []string{"US"}}},
+		}}},
+		Ads:       []DesiredAd{{Key: "a", AdsetKey: "us", CreativeKey: "cr", Ad: Ad{Name: "A"}}},
+		Creatives: []DesiredCreative{{Key: "cr", Creative: AdCreative{Name: "Creative"}}},
+	}
+
+	p, err := service.Plan(ctx, "1", desired, nil, PlanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Changes) != 4 || !strings.Contains(p.String(), "Plan: 4 to create, 0 to update") {
+		t.Fatalf("unexpected plan\n%s", p)
+	}
+	state, err := service.Apply(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ReconcileState{
+		Campaigns: map[string]string{"launch": "campaigns-1"},
+		Adsets:    map[string]string{"us": "adsets-1"},
+		Ads:       map[string]string{"a": "ads-1"},
+		Creatives: map[string]string{"cr": "adcreatives-1"},
+	}
+	if !reflect.DeepEqual(state, want) {
+		t.Fatalf("state = %+v", state)
+	}
+	if !strings.Contains(posts[2], `"campaign_id":"campaigns-1"`) || !strings.Contains(posts[3], `"creative":{"creative_id":"adcreatives-1"}`) {
+		t.Fatalf("parents not set: %v", posts)
+	}
+
+	live = `{"data":[{"id":"campaigns-1","name":"Launch","objective":"OUTCOME_TRAFFIC","status":"PAUSED","adsets":{"data":[` +
+		`{"id":"adsets-1","campaign_id":"campaigns-1","name":"US","daily_budget":"1000","status":"ACTIVE","start_time":"2026-01-01T04:00:00-0800",` +
+		`"targeting":{"age_min":18,"age_max":65,"geo_locations":{"countries":["US"],"location_types":["home","recent"]},"targeting_automation":{"advantage_audience":0}},"ads":{"data":[` +
+		`{"id":"ads-1","adset_id":"adsets-1","name":"A","status":"ACTIVE","creative":{"id":"adcreatives-1","name":"Creative"}},` +
+		`{"id":"ads-2","adset_id":"adsets-1","name":"B","status":"ACTIVE","creative":{"id":"adcreatives-1","name":"Creative"}}]}}]}}]}`
+	creatives = `{"data":[{"id":"adcreatives-1","name":"Creative"}]}`
+	state.Ads["b"] = "ads-2"
+	p, err = service.Plan(ctx, "1", desired, state, PlanOptions{Prune: PrunePause})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Changes) != 2 || p.Changes[0].Action != PlanUpdate || p.Changes[0].ID != "adsets-1" || p.Changes[1].Action != PlanPause || p.Changes[1].ID != "ads-2" {
+		t.Fatalf("unexpected plan\n%s", p)
+	}
+	if !strings.Contains(p.String(), `daily_budget: "1000" => "2000"`) {
+		t.Fatalf("field change missing\n%s", p)
+	}
+	posts = nil
+	if _, err := service.Apply(ctx, p); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{`adsets-1 {"daily_budget":"2000"}`, `ads-2 {"status":"PAUSED"}`}; !reflect.DeepEqual(posts, want) {
+		t.Fatalf("posts = %v, want %v", posts, want)
+	}
+
+	live = strings.NewReplacer(`"daily_budget":"1000"`, `"daily_budget":"2000"`, `"name":"B","status":"ACTIVE"`, `"name":"B","status":"PAUSED"`).Replace(live)
+	p, err = service.Plan(ctx, "1", desired, state, PlanOptions{Prune: PrunePause})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Empty() {
+		t.Fatalf("expected empty plan after apply\n%s", p)
+	}
+
+	desired.Creatives[0].Creative.Name = "Creative 2"
+	p, err = service.Plan(ctx, "1", desired, state, PlanOptions{Prune: PruneDelete})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(p.String(), "Plan: 0 to create, 1 to update, 1 to replace, 0 to pause, 2 to delete") {
+		t.Fatalf("unexpected replace plan\n%s", p)
+	}
+	posts = nil
+	state, err = service.Apply(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{
+		`act_1/adcreatives {"account_id":"1","name":"Creative 2"}`,
+		`ads-1 {"creative":{"creative_id":"adcreatives-2"}}`,
+		"DELETE ads-2",
+		"DELETE adcreatives-1",
+	}; !reflect.DeepEqual(posts, want) {
+		t.Fatalf("posts = %v, want %v", posts, want)
+	}
+	if state.Creatives["cr"] != "adcreatives-2" || state.Ads["b"] != "" {
+		t.Fatalf("state = %+v", state)
+	}
+
+	desired.Ads[0].CreativeKey = "missing"
+	if _, err := service.Plan(ctx, "1", desired, state, PlanOptions{}); err == nil {
+		t.Fatal("expected error for unknown creative")
+	}
+}
+
+type reconcileRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f reconcileRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,187 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// SavedAudienceService contains all methods for working on saved audiences.
+type SavedAudienceService struct {
+	c *fb.Client
+	v Version
+}
+
+// savedAudienceFields are the fields requested by Get and List.
+var savedAudienceFields = []string{
+	"id",
+	"name",
+	"account",
+	"description",
+	"targeting",
+	"approximate_count_lower_bound",
+	"approximate_count_upper_bound",
+	"run_status",
+	"operation_status",
+	"permission_for_actions",
+	"sentence_lines",
+	"time_created",
+	"time_updated",
+}
+
+// SavedAudience https://developers.facebook.com/docs/marketing-api/reference/saved-audience/
+type SavedAudience struct {
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Targeting   *Targeting `json:"targeting,omitempty"`
+
+	// read only fields
+	Account                    *IDContainer                  `json:"account,omitempty"`
+	ApproximateCountLowerBound int                           `json:"approximate_count_lower_bound,omitempty"`
+	ApproximateCountUpperBound int                           `json:"approximate_count_upper_bound,omitempty"`
+	RunStatus                  string                        `json:"run_status,omitempty"`
+	OperationStatus            *AudienceStatus               `json:"operation_status,omitempty"`
+	PermissionForActions       *AudiencePermissionForActions `json:"permission_for_actions,omitempty"`
+	SentenceLines              json.RawMessage               `json:"sentence_lines,omitempty"`
+	TimeCreated                fb.Time                       `json:"time_created,omitzero"`
+	TimeUpdated                fb.Time                       `json:"time_updated,omitzero"`
+}
+
+// AudienceSize returns the approximate count bounds.
+func (sa SavedAudience) AudienceSize() AudienceSize {
+	return AudienceSize{
+		LowerBound: uint64(sa.ApproximateCountLowerBound),
+		UpperBound: uint64(sa.ApproximateCountUpperBound),
+	}
+}
+
+// ApplyTo replaces the targeting of the adset with a copy of the saved audience's targeting.
+// Placements of the adset are kept if the saved audience has none.
+func (sa SavedAudience) ApplyTo(a *Adset) error {
+	if sa.Targeting == nil {
+		return fmt.Errorf("saved audience %s has no targeting", sa.ID)
+	}
+
+	b, err := json.Marshal(sa.Targeting)
+	if err != nil {
+		return err
+	}
+	t := &Targeting{}
+	err = json.Unmarshal(b, t)
+	if err != nil {
+		return err
+	}
+
+	if old := a.Targeting; old != nil && len(t.PublisherPlatforms) == 0 {
+		t.PublisherPlatforms = old.PublisherPlatforms
+		t.FacebookPositions = old.FacebookPositions
+		t.InstagramPositions = old.InstagramPositions
+		t.AudienceNetworkPositions = old.AudienceNetworkPositions
+		t.MessengerPositions = old.MessengerPositions
+		t.DevicePlatforms = old.DevicePlatforms
+	}
+	a.Targeting = t
+
+	return nil
+}
+
+// Get returns a single saved audience.
+func (sas *SavedAudienceService) Get(ctx context.Context, id string) (*SavedAudience, error) {
+	res := &SavedAudience{}
+	err := sas.c.GetJSON(ctx, fb.NewRoute(sas.v.Name, "/%s", id).Fields(savedAudienceFields...).String(), res)
+	if err != nil {
+		if fb.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// List returns all saved audiences of an account.
+func (sas *SavedAudienceService) List(ctx context.Context, act string) ([]SavedAudience, error) {
+	res := []SavedAudience{}
+	route := fb.NewRoute(sas.v.Name, "/act_%s/saved_audiences", act).
+		Limit(250).
+		Fields(savedAudienceFields...)
+	err := sas.c.GetList(ctx, route.String(), &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create creates a saved audience and returns its id.
+func (sas *SavedAudienceService) Create(ctx context.Context, act string, sa SavedAudience) (string, error) {
+	if sa.ID != "" {
+		return "", fmt.Errorf("cannot create saved audience that already exists: %s", sa.ID)
+	} else if act == "" {
+		return "", errors.New("cannot create saved audience without account id")
+	} else if sa.Targeting == nil {
+		return "", errors.New("cannot create saved audience without targeting")
+	}
+
+	res := &fb.MinimalResponse{}
+	err := sas.c.PostJSON(ctx, fb.NewRoute(sas.v.Name, "/act_%s/saved_audiences", act).String(), savedAudienceRequest(sa), res)
+	if err != nil {
+		return "", err
+	} else if err = res.GetError(); err != nil {
+		return "", err
+	} else if res.ID == "" {
+		return "", fmt.Errorf("creating saved audience failed")
+	}
+
+	return res.ID, nil
+}
+
+// Update updates the name, description and targeting of a saved audience.
+func (sas *SavedAudienceService) Update(ctx context.Context, sa SavedAudience) error {
+	if sa.ID == "" {
+		return errors.New("cannot update saved audience without id")
+	}
+
+	res := &fb.MinimalResponse{}
+	err := sas.c.PostJSON(ctx, fb.NewRoute(sas.v.Name, "/%s", sa.ID).String(), savedAudienceRequest(sa), res)
+	if err != nil {
+		return err
+	} else if err = res.GetError(); err != nil {
+		return err
+	} else if !res.Success && res.ID == "" {
+		return fmt.Errorf("updating the saved audience failed")
+	}
+
+	return nil
+}
+
+// Delete removes a saved audience.
+func (sas *SavedAudienceService) Delete(ctx context.Context, id string) error {
+	return sas.c.Delete(ctx, fb.NewRoute(sas.v.Name, "/%s", id).String())
+}
+
+// ApplyTo gets the saved audience and applies its targeting to the adset, see SavedAudience.ApplyTo.
+func (sas *SavedAudienceService) ApplyTo(ctx context.Context, id string, a *Adset) error {
+	sa, err := sas.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if sa == nil {
+		return fmt.Errorf("did not find saved audience %s", id)
+	}
+
+	return sa.ApplyTo(a)
+}
+
+// savedAudienceRequest contains only the writable fields of a SavedAudience.
+func savedAudienceRequest(sa SavedAudience) interface{} {
+	return struct {
+		Name        string     `json:"name,omitempty"`
+		Description string     `json:"description,omitempty"`
+		Targeting   *Targeting `json:"targeting,omitempty"`
+	}{sa.Name, sa.Description, sa.Targeting}
+}
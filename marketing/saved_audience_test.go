@@ -0,0 +1,109 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestSavedAudienceApplyTo(t *testing.T) {
+	sa := SavedAudience{
+		ID: "1",
+		Targeting: &Targeting{
+			AgeMin:       18,
+			GeoLocations: &GeoLocations{Countries: []string{"DE"}},
+			FlexibleSpec: []FlexibleSpec{{Interests: []IDContainer{{ID: "6003", Name: "Movies"}}}},
+		},
+	}
+	a := &Adset{Targeting: &Targeting{AgeMin: 21, PublisherPlatforms: []string{"instagram"}, InstagramPositions: []string{"stream"}}}
+	if err := sa.ApplyTo(a); err != nil {
+		t.Fatal(err)
+	}
+	if a.Targeting.AgeMin != 18 || fmt.Sprint(a.Targeting.PublisherPlatforms, a.Targeting.InstagramPositions) != "[instagram] [stream]" {
+		t.Fatalf("unexpected targeting %+v", a.Targeting)
+	}
+
+	// the adset gets a copy of the targeting
+	a.Targeting.FlexibleSpec[0].Interests[0].ID = "6004"
+	if sa.Targeting.FlexibleSpec[0].Interests[0].ID != "6003" {
+		t.Fatal("saved audience targeting was modified")
+	}
+
+	if err := (SavedAudience{ID: "2"}).ApplyTo(a); err == nil {
+		t.Fatal("expected error for missing targeting")
+	}
+}
+
+func TestSavedAudienceService(t *testing.T) {
+	requests := []string{}
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: savedAudienceRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		body := `{"success":true}`
+		var b []byte
+		if request.Body != nil {
+			var err error
+			b, err = io.ReadAll(request.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		requests = append(requests, request.Method+" "+request.URL.Path+" "+strings.TrimSpace(string(b)))
+		if request.URL.Path == "/v24.0/act_1/saved_audiences" {
+			body = `{"id":"sa1"}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &SavedAudienceService{c: client, v: Version{Name: "v24.0"}}
+	ctx := context.Background()
+
+	sa := SavedAudience{Name: "DE adults", Targeting: &Targeting{AgeMin: 18, GeoLocations: &GeoLocations{Countries: []string{"DE"}}}}
+	id, err := service.Create(ctx, "1", sa)
+	if err != nil || id != "sa1" {
+		t.Fatalf("Create() = %s, %v", id, err)
+	}
+	sa.ID, sa.Description = id, "Germany"
+	if err := service.Update(ctx, sa); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	targeting := `"targeting":{"age_min":18,"geo_locations":{"countries":["DE"]},"targeting_relaxation_types":{"custom_audience":0,"lookalike":0}}`
+	want := []string{
+		`POST /v24.0/act_1/saved_audiences {"name":"DE adults",` + targeting + `}`,
+		`POST /v24.0/sa1 {"name":"DE adults","description":"Germany",` + targeting + `}`,
+		`DELETE /v24.0/sa1 `,
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(want) {
+		t.Fatalf("requests = %v\nwant %v", requests, want)
+	}
+
+	if _, err := service.Create(ctx, "1", sa); err == nil {
+		t.Fatal("expected error for creating an existing saved audience")
+	}
+	if _, err := service.Create(ctx, "1", SavedAudience{Name: "empty"}); err == nil {
+		t.Fatal("expected error for missing targeting")
+	}
+	if err := service.Update(ctx, SavedAudience{Name: "no id"}); err == nil {
+		t.Fatal("expected error for missing id")
+	}
+}
+
+type savedAudienceRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f savedAudienceRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -1,4 +1,4 @@
-package v22
+package marketing
 
 import (
 	"context"
@@ -9,6 +9,7 @@ import (
 // SearchService performs searches on the graph API.
 type SearchService struct {
 	c *fb.Client
+	v Version
 }
 
 const (
@@ -18,7 +19,7 @@ const (
 
 // GetAdGeoLocations returns all AdGeoLocations.
 func (s *SearchService) GetAdGeoLocations(ctx context.Context) ([]AdGeoLocation, error) {
-	rb := fb.NewRoute(Version, "/search").
+	rb := fb.NewRoute(s.v.Name, "/search").
 		Type("adgeolocation").
 		LocationTypes("country", "city", "region").
 		Limit(getGeoLocationLimit)
@@ -32,7 +33,7 @@ func (s *SearchService) GetAdGeoLocations(ctx context.Context) ([]AdGeoLocation,
 }
 
 func (s *SearchService) GetRegions(ctx context.Context, country string) ([]AdGeoLocation, error) {
-	rb := fb.NewRoute(Version, "/search").
+	rb := fb.NewRoute(s.v.Name, "/search").
 		Type("adgeolocation").
 		LocationTypes("region").
 		Limit(getGeoLocationLimit)
@@ -60,7 +61,7 @@ type AdGeoLocation struct {
 
 // GetDevices returns all devices.
 func (s *SearchService) GetDevices(ctx context.Context) ([]Device, error) {
-	rb := fb.NewRoute(Version, "/search").
+	rb := fb.NewRoute(s.v.Name, "/search").
 		Type("adTargetingCategory").
 		Class("user_device").
 		Limit(getDevicesResultsLimit)
@@ -84,7 +85,7 @@ type Device struct {
 
 // GetOperatingSystems returns all operating systems.
 func (s *SearchService) GetOperatingSystems(ctx context.Context) ([]OperatingSystem, error) {
-	rb := fb.NewRoute(Version, "/search").
+	rb := fb.NewRoute(s.v.Name, "/search").
 		Type("adTargetingCategory").
 		Class("user_os").
 		Limit(getDevicesResultsLimit)
@@ -107,7 +108,7 @@ type OperatingSystem struct {
 
 // GetAdLocales returns all ad locales.
 func (s *SearchService) GetAdLocales(ctx context.Context) ([]AdLocale, error) {
-	rb := fb.NewRoute(Version, "/search").
+	rb := fb.NewRoute(s.v.Name, "/search").
 		Type("adlocale").
 		Limit(1000)
 	res := []AdLocale{}
@@ -137,7 +138,7 @@ type TargetingOptionStatus struct {
 
 // ValidateInterests validates a list of interests and returns a list of valid and a list of invalid IDs.
 func (s *SearchService) ValidateInterests(ctx context.Context, externalIDs []string) (validIDs []string, invalidIDs []string, err error) {
-	rb := fb.NewRoute(Version, "/search").
+	rb := fb.NewRoute(s.v.Name, "/search").
 		Type("targetingoptionstatus").
 		TargetingOptionList(externalIDs...).
 		Limit(1000)
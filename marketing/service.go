@@ -0,0 +1,104 @@
+// Package marketing contains the services for working with the Facebook Marketing API.
+// The graph API version is chosen at runtime, so upgrading is a matter of passing a
+// different version name. The versioned packages (e.g. marketing/v24) are thin
+// wrappers pinning a version and registering its field selections.
+package marketing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// Service interacts with the Facebook Marketing API.
+type Service struct {
+	*fb.Client
+	v                 Version
+	Activities        *ActivityService
+	AdAccounts        *AdAccountService
+	AdCreatives       *AdCreativeService
+	AdRules           *AdRuleService
+	Adsets            *AdsetService
+	Ads               *AdService
+	Audiences         *AudienceService
+	Campaigns         *CampaignService
+	Conversions       *ConversionsService
+	CustomConversions *CustomConversionService
+	Events            *EventService
+	Insights          *InsightsService
+	Interests         *InterestService
+	Images            *ImageService
+	Pages             *PageService
+	Posts             *PostService
+	SavedAudiences    *SavedAudienceService
+	Search            *SearchService
+	Videos            *VideoService
+}
+
+// New initializes a new Service for the given graph API version and all the Services contained.
+// Versions other than LatestVersion must be registered by importing their package, e.g. marketing/v22.
+func New(l log.Logger, accessToken, appSecret, version string) (*Service, error) {
+	return NewWithClient(l, fb.NewClient(l, accessToken, appSecret), version)
+}
+
+// NewWithConfig initializes a new Service with the given rate-limit configuration.
+func NewWithConfig(l log.Logger, accessToken, appSecret, version string, cfg fb.RateLimitConfig) (*Service, error) {
+	return NewWithClient(l, fb.NewClientWithConfig(l, accessToken, appSecret, cfg), version)
+}
+
+// NewWithClient initializes a new Service using a pre-configured fb.Client.
+// The client is validated by making a /me call.
+func NewWithClient(l log.Logger, c *fb.Client, version string) (*Service, error) {
+	v, err := LookupVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	err = c.GetJSON(context.Background(), fb.NewRoute(v.Name, "/me").String(), &struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		Client:            c,
+		v:                 v,
+		Activities:        &ActivityService{c, v},
+		AdAccounts:        &AdAccountService{c, v},
+		AdCreatives:       &AdCreativeService{c, v, fb.NewStatsContainer()},
+		AdRules:           &AdRuleService{c, v},
+		Adsets:            &AdsetService{c, v},
+		Ads:               &AdService{c, v},
+		Audiences:         &AudienceService{c, v, fb.NewStatsContainer()},
+		Campaigns:         &CampaignService{c, v},
+		Conversions:       &ConversionsService{c, v},
+		CustomConversions: &CustomConversionService{c, v},
+		Events:            &EventService{c, v},
+		Insights:          newInsightsService(l, c, v),
+		Interests:         &InterestService{c, v},
+		Images:            &ImageService{c, v},
+		Pages:             &PageService{c, v},
+		Posts:             &PostService{c, v, fb.NewStatsContainer()},
+		SavedAudiences:    &SavedAudienceService{c, v},
+		Search:            &SearchService{c, v},
+		Videos:            &VideoService{c, v},
+	}, nil
+}
+
+// Version returns the name of the graph API version the Service talks to.
+func (s *Service) Version() string {
+	return s.v.Name
+}
+
+// GetMetadata returns the metadata of a graph API object.
+func (s *Service) GetMetadata(ctx context.Context, id string) (*fb.Metadata, error) {
+	res := &fb.MetadataContainer{}
+	err := s.Client.GetJSON(ctx, fb.NewRoute(s.v.Name, "/%s", id).Metadata(true).String(), res)
+	if err != nil {
+		return nil, err
+	} else if res.Metadata == nil {
+		return nil, errors.New("could not get metadata")
+	}
+
+	return res.Metadata, nil
+}
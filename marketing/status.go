@@ -0,0 +1,157 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+// Statuses that can be set on campaigns, adsets and ads.
+const (
+	StatusActive   = "ACTIVE"
+	StatusPaused   = "PAUSED"
+	StatusArchived = "ARCHIVED"
+	StatusDeleted  = "DELETED"
+)
+
+// statusBatchSize is the maximum number of requests in a batch request.
+const statusBatchSize = 50
+
+// Types of the objects in a StatusResult.
+const (
+	ObjectTypeCampaign = "campaign"
+	ObjectTypeAdset    = "adset"
+	ObjectTypeAd       = "ad"
+)
+
+// StatusResult is the outcome of changing the status of a single object.
+type StatusResult struct {
+	ID     string
+	Type   string
+	Status string
+	Err    error
+}
+
+// setStatus updates the status of a campaign, adset or ad.
+func setStatus(ctx context.Context, c *fb.Client, v Version, id, status string) error {
+	if id == "" {
+		return fmt.Errorf("cannot set status %s without id", status)
+	}
+
+	res := &fb.MinimalResponse{}
+	err := c.PostJSON(ctx, fb.NewRoute(v.Name, "/%s", id).String(), map[string]string{"status": status}, res)
+	if err != nil {
+		return err
+	} else if err = res.GetError(); err != nil {
+		return err
+	} else if !res.Success && res.ID == "" {
+		return fmt.Errorf("setting status %s of %s failed", status, id)
+	}
+
+	return nil
+}
+
+// SetStatus sets the status of the campaigns using batch requests.
+// If cascade is set, the status is also set on all adsets and ads of the campaigns.
+// Parents are activated before their children, all other statuses are set on the children first.
+// A result is returned for every object, the error is only set if the objects could not be listed
+// or a batch request failed as a whole. In that case the objects of the following levels are skipped
+// and their results contain an error as well.
+func (cs *CampaignService) SetStatus(ctx context.Context, status string, cascade bool, campaignIDs ...string) ([]StatusResult, error) {
+	switch status {
+	case StatusActive, StatusPaused, StatusArchived, StatusDeleted:
+	default:
+		return nil, fmt.Errorf("cannot set unknown status '%s'", status)
+	}
+
+	levels := [][]StatusResult{statusResults(ObjectTypeCampaign, status, campaignIDs)}
+	if cascade {
+		for _, t := range []string{ObjectTypeAdset, ObjectTypeAd} {
+			ids := []string{}
+			for _, campaignID := range campaignIDs {
+				children := []IDContainer{}
+				err := cs.c.GetList(ctx, fb.NewRoute(cs.v.Name, "/%s/%ss", campaignID, t).Fields("id").Limit(1000).String(), &children)
+				if err != nil {
+					return nil, fmt.Errorf("listing %ss of campaign %s: %w", t, campaignID, err)
+				}
+				for _, c := range children {
+					ids = append(ids, c.ID)
+				}
+			}
+			levels = append(levels, statusResults(t, status, ids))
+		}
+	}
+	if status != StatusActive {
+		for i, j := 0, len(levels)-1; i < j; i, j = i+1, j-1 {
+			levels[i], levels[j] = levels[j], levels[i]
+		}
+	}
+
+	results := []StatusResult{}
+	var err error
+	for _, level := range levels {
+		if err != nil {
+			// the order of the levels must be kept, so the remaining levels are skipped
+			for i := range level {
+				level[i].Err = fmt.Errorf("skipped after previous level failed: %w", err)
+			}
+		} else {
+			err = setStatuses(ctx, cs.c, cs.v, level)
+		}
+		results = append(results, level...)
+	}
+
+	return results, err
+}
+
+func statusResults(objectType, status string, ids []string) []StatusResult {
+	results := make([]StatusResult, len(ids))
+	for i, id := range ids {
+		results[i] = StatusResult{ID: id, Type: objectType, Status: status}
+	}
+
+	return results
+}
+
+// setStatuses sets the statuses in batches and stores the outcome of each request in the results.
+func setStatuses(ctx context.Context, c *fb.Client, v Version, results []StatusResult) error {
+	for start := 0; start < len(results); start += statusBatchSize {
+		end := start + statusBatchSize
+		if end > len(results) {
+			end = len(results)
+		}
+
+		batch := make([]graphBatchRequest, 0, end-start)
+		for _, r := range results[start:end] {
+			batch = append(batch, graphBatchRequest{
+				Method:      http.MethodPost,
+				RelativeURL: r.ID,
+				Body:        url.Values{"status": {r.Status}}.Encode(),
+			})
+		}
+
+		responses, err := doBatch(ctx, c, v, batch)
+		if err != nil {
+			err = fmt.Errorf("setting status of %d objects: %w", len(batch), err)
+			for i := start; i < len(results); i++ {
+				results[i].Err = err
+			}
+			return err
+		}
+		for i, response := range responses {
+			r := &results[start+i]
+			res := &fb.MinimalResponse{}
+			err = decodeGraphBatchResponse(response, res)
+			if err != nil {
+				r.Err = err
+			} else if !res.Success && res.ID == "" {
+				r.Err = fmt.Errorf("setting status %s of %s %s failed", r.Status, r.Type, r.ID)
+			}
+		}
+	}
+
+	return nil
+}
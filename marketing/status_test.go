@@ -0,0 +1,113 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func TestCampaignSetStatusCascade(t *testing.T) {
+	order := []string{}
+	failBatch := false
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: statusRoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		body := ""
+		switch request.URL.Path {
+		case "/v24.0/c1/adsets":
+			body = `{"data":[{"id":"as1"},{"id":"as2"}]}`
+		case "/v24.0/c1/ads":
+			body = `{"data":[{"id":"ad1"},{"id":"ad2"},{"id":"ad3"}]}`
+		case "/v24.0/":
+			if failBatch {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Status:     "400 Bad Request",
+					Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`)),
+					Request:    request,
+				}, nil
+			}
+			b, err := io.ReadAll(request.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			form, err := url.ParseQuery(string(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			batch := []graphBatchRequest{}
+			if err := json.Unmarshal([]byte(form.Get("batch")), &batch); err != nil {
+				t.Fatal(err)
+			}
+			responses := make([]graphBatchResponse, len(batch))
+			for i, r := range batch {
+				if r.Method != http.MethodPost || r.Body != "status=PAUSED" {
+					t.Fatalf("unexpected batch request %+v", r)
+				}
+				order = append(order, r.RelativeURL)
+				responses[i] = graphBatchResponse{Code: http.StatusOK, Body: `{"success":true}`}
+				if r.RelativeURL == "ad2" {
+					responses[i] = graphBatchResponse{Code: http.StatusBadRequest, Body: `{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`}
+				}
+			}
+			res, err := json.Marshal(responses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body = string(res)
+		default:
+			t.Fatalf("unexpected request %s", request.URL)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})}
+	service := &CampaignService{c: client, v: Version{Name: "v24.0"}}
+
+	results, err := service.SetStatus(context.Background(), StatusPaused, true, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(order, ","); got != "ad1,ad2,ad3,as1,as2,c1" {
+		t.Fatalf("unexpected order %s", got)
+	}
+	if len(results) != 6 {
+		t.Fatalf("got %d results, want 6", len(results))
+	}
+	for _, r := range results {
+		if (r.ID == "ad2") != (r.Err != nil) {
+			t.Fatalf("unexpected result %+v", r)
+		}
+	}
+
+	failBatch = true
+	results, err = service.SetStatus(context.Background(), StatusActive, true, "c1")
+	if err == nil || len(results) != 6 {
+		t.Fatalf("got %d results and err %v, want 6 results and an error", len(results), err)
+	}
+	for _, r := range results {
+		if r.Err == nil || (r.Type != ObjectTypeCampaign) != strings.Contains(r.Err.Error(), "skipped") {
+			t.Fatalf("unexpected result %+v", r)
+		}
+	}
+
+	if _, err := service.SetStatus(context.Background(), "STOPPED", false, "c1"); err == nil {
+		t.Fatal("expected error for unknown status")
+	}
+}
+
+type statusRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f statusRoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// AppData entity https://developers.facebook.com/docs/marketing-api/conversions-api/app-events
+// It is required for events with the action source App.
+type AppData struct {
+	AdvertiserTrackingEnabled  Flag     `json:"advertiser_tracking_enabled"`
+	ApplicationTrackingEnabled Flag     `json:"application_tracking_enabled"`
+	ExtInfo                    ExtInfo  `json:"extinfo"`
+	CampaignIDs                string   `json:"campaign_ids,omitempty"`
+	InstallReferrer            string   `json:"install_referrer,omitempty"`
+	InstallerPackage           string   `json:"installer_package,omitempty"`
+	URLSchemes                 []string `json:"url_schemes,omitempty"`
+	WindowsAttributionID       string   `json:"windows_attribution_id,omitempty"`
+}
+
+func NewAppData(advertiserTrackingEnabled, applicationTrackingEnabled bool, extInfo ExtInfo) AppData {
+	return AppData{
+		AdvertiserTrackingEnabled:  Flag(advertiserTrackingEnabled),
+		ApplicationTrackingEnabled: Flag(applicationTrackingEnabled),
+		ExtInfo:                    extInfo,
+	}
+}
+
+// Validate checks the required extinfo values.
+func (ad AppData) Validate() error {
+	switch ad.ExtInfo.Version {
+	case ExtInfoAndroid, ExtInfoIOS:
+	case "":
+		return errors.New("missing extinfo version")
+	default:
+		return fmt.Errorf("invalid extinfo version '%s'", ad.ExtInfo.Version)
+	}
+	if ad.ExtInfo.PackageName == "" {
+		return errors.New("missing extinfo package name")
+	}
+
+	return nil
+}
+
+// Flag is a bool that is encoded as 0 or 1.
+type Flag bool
+
+func (f Flag) MarshalJSON() ([]byte, error) {
+	if f {
+		return []byte("1"), nil
+	}
+
+	return []byte("0"), nil
+}
+
+func (f *Flag) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case "1", "true":
+		*f = true
+	case "0", "false", "null":
+		*f = false
+	default:
+		return fmt.Errorf("invalid flag %s", b)
+	}
+
+	return nil
+}
+
+// Versions of ExtInfo.
+const (
+	ExtInfoAndroid = "a2"
+	ExtInfoIOS     = "i2"
+)
+
+// ExtInfo describes the device of an app event, it is encoded as the array of 16 strings the API expects.
+type ExtInfo struct {
+	Version              string
+	PackageName          string
+	ShortVersion         string
+	LongVersion          string
+	OSVersion            string
+	DeviceModel          string
+	Locale               string
+	TimezoneAbbreviation string
+	Carrier              string
+	ScreenWidth          string
+	ScreenHeight         string
+	ScreenDensity        string
+	CPUCores             string
+	ExternalStorageSize  string
+	FreeSpace            string
+	DeviceTimezone       string
+}
+
+// fields returns pointers to the values in the order of the extinfo array.
+func (ei *ExtInfo) fields() [16]*string {
+	return [16]*string{
+		&ei.Version, &ei.PackageName, &ei.ShortVersion, &ei.LongVersion, &ei.OSVersion, &ei.DeviceModel,
+		&ei.Locale, &ei.TimezoneAbbreviation, &ei.Carrier, &ei.ScreenWidth, &ei.ScreenHeight,
+		&ei.ScreenDensity, &ei.CPUCores, &ei.ExternalStorageSize, &ei.FreeSpace, &ei.DeviceTimezone,
+	}
+}
+
+func (ei ExtInfo) MarshalJSON() ([]byte, error) {
+	a := [16]string{}
+	for i, f := range ei.fields() {
+		a[i] = *f
+	}
+
+	return json.Marshal(a)
+}
+
+func (ei *ExtInfo) UnmarshalJSON(b []byte) error {
+	a := []string{}
+	err := json.Unmarshal(b, &a)
+	if err != nil {
+		return err
+	} else if len(a) > 16 {
+		return fmt.Errorf("extinfo has %d values, want at most 16", len(a))
+	}
+
+	res := ExtInfo{}
+	fields := res.fields()
+	for i, v := range a {
+		*fields[i] = v
+	}
+	*ei = res
+
+	return nil
+}
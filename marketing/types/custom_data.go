@@ -0,0 +1,203 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// CustomData entity https://developers.facebook.com/docs/marketing-api/conversions-api/parameters/custom-data
+type CustomData struct {
+	// Value is a pointer, so that a value of 0 is still sent.
+	Value            *float64 `json:"value,omitempty"`
+	Currency         string   `json:"currency,omitempty"`
+	ContentName      string   `json:"content_name,omitempty"`
+	ContentCategory  string   `json:"content_category,omitempty"`
+	ContentIDs       []string `json:"content_ids,omitempty"`
+	ContentType      string   `json:"content_type,omitempty"`
+	Contents         Contents `json:"contents,omitempty"`
+	OrderID          string   `json:"order_id,omitempty"`
+	PredictedLTV     float64  `json:"predicted_ltv,omitempty"`
+	NumItems         int      `json:"num_items,omitempty"`
+	SearchString     string   `json:"search_string,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	DeliveryCategory string   `json:"delivery_category,omitempty"`
+
+	// Custom contains custom properties, they are sent next to the standard parameters.
+	Custom map[string]interface{} `json:"-"`
+}
+
+// Content types of CustomData.ContentType.
+const (
+	ContentTypeProduct      = "product"
+	ContentTypeProductGroup = "product_group"
+)
+
+func NewCustomData() CustomData {
+	return CustomData{}
+}
+
+// WithValue sets the monetary value and the ISO 4217 currency code.
+func (cd CustomData) WithValue(value float64, currency string) CustomData {
+	cd.Value = &value
+	cd.Currency = strings.ToUpper(strings.TrimSpace(currency))
+	return cd
+}
+
+// WithContents sets the contents and derives the content ids, number of items and content type from them.
+func (cd CustomData) WithContents(contents Contents) CustomData {
+	cd.Contents = contents
+	cd.ContentIDs = make([]string, 0, len(contents))
+	cd.NumItems = 0
+	for _, c := range contents {
+		cd.ContentIDs = append(cd.ContentIDs, c.ID)
+		cd.NumItems += c.Quantity
+	}
+	if cd.ContentType == "" {
+		cd.ContentType = ContentTypeProduct
+	}
+	return cd
+}
+
+func (cd CustomData) WithContentIDs(contentType string, contentIDs ...string) CustomData {
+	cd.ContentType = contentType
+	cd.ContentIDs = contentIDs
+	return cd
+}
+
+func (cd CustomData) WithOrderID(orderID string) CustomData {
+	cd.OrderID = orderID
+	return cd
+}
+
+func (cd CustomData) WithPredictedLTV(predictedLTV float64) CustomData {
+	cd.PredictedLTV = predictedLTV
+	return cd
+}
+
+func (cd CustomData) WithSearchString(searchString string) CustomData {
+	cd.SearchString = searchString
+	return cd
+}
+
+func (cd CustomData) WithStatus(status string) CustomData {
+	cd.Status = status
+	return cd
+}
+
+// WithCustomProperty adds a custom property, it must not collide with a standard parameter.
+func (cd CustomData) WithCustomProperty(key string, value interface{}) CustomData {
+	custom := make(map[string]interface{}, len(cd.Custom)+1)
+	for k, v := range cd.Custom {
+		custom[k] = v
+	}
+	custom[key] = value
+	cd.Custom = custom
+	return cd
+}
+
+// MarshalJSON merges the custom properties into the standard parameters.
+func (cd CustomData) MarshalJSON() ([]byte, error) {
+	type customData CustomData
+	b, err := json.Marshal(customData(cd))
+	if err != nil || len(cd.Custom) == 0 {
+		return b, err
+	}
+
+	m := map[string]interface{}{}
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range cd.Custom {
+		if _, ok := m[k]; ok {
+			return nil, fmt.Errorf("custom property '%s' collides with a standard parameter", k)
+		}
+		m[k] = v
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes the standard parameters and collects all other properties in Custom.
+func (cd *CustomData) UnmarshalJSON(b []byte) error {
+	type customData CustomData
+	res := customData{}
+	err := json.Unmarshal(b, &res)
+	if err != nil {
+		return err
+	}
+
+	m := map[string]interface{}{}
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return err
+	}
+	for _, k := range customDataKeys {
+		delete(m, k)
+	}
+	if len(m) > 0 {
+		res.Custom = m
+	}
+	*cd = CustomData(res)
+
+	return nil
+}
+
+// customDataKeys are the json keys of the standard parameters.
+var customDataKeys = func() []string {
+	var keys []string
+	t := reflect.TypeOf(CustomData{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+
+	return keys
+}()
+
+// Validate checks the parameters required for the event, e.g. a Purchase needs value and currency.
+func (cd CustomData) Validate(eventName string) error {
+	if cd.Currency != "" && !isCurrency(cd.Currency) {
+		return fmt.Errorf("currency '%s' is not an ISO 4217 code", cd.Currency)
+	} else if cd.Value != nil && cd.Currency == "" {
+		return errors.New("value requires currency")
+	} else if cd.Value != nil && *cd.Value < 0 {
+		return fmt.Errorf("negative value %v", *cd.Value)
+	}
+
+	if cd.ContentType != "" && cd.ContentType != ContentTypeProduct && cd.ContentType != ContentTypeProductGroup {
+		return fmt.Errorf("invalid content_type '%s'", cd.ContentType)
+	}
+	for i, c := range cd.Contents {
+		if c.ID == "" {
+			return fmt.Errorf("content %d has no id", i)
+		} else if c.Quantity < 0 || c.ItemPrice < 0 {
+			return fmt.Errorf("content %d has a negative quantity or item_price", i)
+		}
+	}
+
+	if eventName == "Purchase" && (cd.Value == nil || cd.Currency == "") {
+		return errors.New("value and currency are required for Purchase events")
+	}
+
+	return nil
+}
+
+func isCurrency(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+
+	return true
+}
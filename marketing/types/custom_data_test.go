@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerEventValidateCustomData(t *testing.T) {
+	now := time.Now()
+	newEvent := func(name string) ServerEvent {
+		return NewServerEvent(name, "id", UnixTime(now.Unix()), PhysicalStore)
+	}
+	extInfo := ExtInfo{Version: ExtInfoAndroid, PackageName: "com.example.app"}
+
+	for _, td := range []struct {
+		name  string
+		event ServerEvent
+		err   string
+	}{
+		{"purchase", newEvent("Purchase").WithCustomData(NewCustomData().WithValue(0, "eur")), ""},
+		{"purchase without custom data", newEvent("Purchase"), "required for Purchase events"},
+		{"purchase without value", newEvent("Purchase").WithContents(Contents{{ID: "sku-1", Quantity: 1}}), "required for Purchase events"},
+		{"value without currency", newEvent("Lead").WithCustomData(CustomData{Value: new(float64)}), "value requires currency"},
+		{"invalid currency", newEvent("Lead").WithCustomData(NewCustomData().WithValue(1, "euro")), "ISO 4217"},
+		{"invalid content type", newEvent("ViewContent").WithCustomData(NewCustomData().WithContentIDs("item", "1")), "invalid content_type"},
+		{"content without id", newEvent("ViewContent").WithContents(Contents{{Quantity: 1}}), "has no id"},
+		{"app event", NewServerEvent("Lead", "id", UnixTime(now.Unix()), App).WithAppData(NewAppData(true, true, extInfo)), ""},
+		{"app event without app data", NewServerEvent("Lead", "id", UnixTime(now.Unix()), App), "require app_data"},
+		{"app event without extinfo", NewServerEvent("Lead", "id", UnixTime(now.Unix()), App).WithAppData(AppData{}), "missing extinfo version"},
+		{"limited data use", newEvent("Lead").WithLimitedDataUse(1, 1000), ""},
+		{"invalid data processing option", ServerEvent{EventName: "Lead", EventTime: UnixTime(now.Unix()), ActionSource: Other, DataProcessingOptions: []string{"GDPR"}}, "invalid data processing option"},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			err := td.event.Validate(now)
+			if td.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if td.err != "" && (err == nil || !strings.Contains(err.Error(), td.err)) {
+				t.Fatalf("err = %v, want error containing %q", err, td.err)
+			}
+		})
+	}
+}
+
+func TestServerEventJSON(t *testing.T) {
+	e := NewServerEvent("Purchase", "id", 1700000000, App).
+		WithCustomData(NewCustomData().WithValue(19.98, "USD").WithOrderID("o-1").WithCustomProperty("coupon", "SPRING")).
+		WithContents(Contents{{ID: "sku-1", Quantity: 2, ItemPrice: 9.99}}).
+		WithAppData(NewAppData(false, true, ExtInfo{Version: ExtInfoIOS, PackageName: "com.example.app"})).
+		WithLimitedDataUse(0, 0)
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"custom_data":{"content_ids":["sku-1"],"content_type":"product","contents":[{"id":"sku-1","item_price":9.99,"quantity":2}],"coupon":"SPRING","currency":"USD","num_items":2,"order_id":"o-1","value":19.98}`,
+		`"app_data":{"advertiser_tracking_enabled":0,"application_tracking_enabled":1,"extinfo":["i2","com.example.app","","","","","","","","","","","","","",""]}`,
+		`"data_processing_options":["LDU"],"data_processing_options_country":0,"data_processing_options_state":0`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("%s does not contain %s", b, want)
+		}
+	}
+
+	res := ServerEvent{}
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, e) {
+		t.Fatalf("round trip = %+v, want %+v", res, e)
+	}
+}
+
+func TestCustomPropertyCollision(t *testing.T) {
+	_, err := json.Marshal(NewCustomData().WithOrderID("o-1").WithCustomProperty("order_id", "o-2"))
+	if err == nil {
+		t.Fatal("expected error for colliding custom property")
+	}
+}
@@ -0,0 +1,179 @@
+package types
+
+// CutomerInfromation entity https://developers.facebook.com/docs/marketing-api/conversions-api/parameters/customer-information-parameters
+// The With* methods of hashed parameters normalize and hash their values, values which
+// already are SHA-256 hashes are passed through and values which are empty after normalization are dropped.
+type CustomerInformation struct {
+	Email            []string `json:"em,omitempty"`
+	PhoneNumber      []string `json:"ph,omitempty"`
+	FirstName        []string `json:"fn,omitempty"`
+	LastName         []string `json:"ln,omitempty"`
+	DateOfBirth      []string `json:"db,omitempty"`
+	Gender           []string `json:"ge,omitempty"`
+	City             []string `json:"ct,omitempty"`
+	State            []string `json:"st,omitempty"`
+	Zip              []string `json:"zp,omitempty"`
+	Country          []string `json:"country,omitempty"`
+	ExternalID       []string `json:"external_id,omitempty"`
+	ClientIPAddress  string   `json:"client_ip_address,omitempty"`
+	ClientUserAgent  string   `json:"client_user_agent,omitempty"`
+	Fbc              string   `json:"fbc,omitempty"`
+	Fbp              string   `json:"fbp,omitempty"`
+	SubscriptionID   string   `json:"subscription_id,omitempty"`
+	FbLoginID        uint64   `json:"fb_login_id,omitempty"`
+	LeadID           uint64   `json:"lead_id,omitempty"`
+	AnonID           string   `json:"anon_id,omitempty"`
+	MadID            string   `json:"madid,omitempty"`
+	PageID           string   `json:"page_id,omitempty"`
+	PageScopedUserID string   `json:"page_scoped_user_id,omitempty"`
+	CtwaClid         string   `json:"ctwa_clid,omitempty"`
+	IgAccountID      string   `json:"ig_account_id,omitempty"`
+	IgSid            string   `json:"ig_sid,omitempty"`
+}
+
+func NewCustomerInformation() CustomerInformation {
+	return CustomerInformation{}
+}
+
+// Adds already hashed email to the struct
+func (ci CustomerInformation) WithHashedEmail(hashedEmail string) CustomerInformation {
+	ci.Email = []string{hashedEmail}
+	return ci
+}
+
+// Adds non-hashed emails to the struct for them to be normalized and hashed.
+func (ci CustomerInformation) WithEmail(emails ...string) CustomerInformation {
+	ci.Email = hashAll(NormalizeEmail, emails)
+	return ci
+}
+
+// Adds non-hashed phone numbers to the struct for them to be normalized and hashed.
+// Phone numbers have to include the country code.
+func (ci CustomerInformation) WithPhoneNumber(phoneNumbers ...string) CustomerInformation {
+	ci.PhoneNumber = hashAll(NormalizePhone, phoneNumbers)
+	return ci
+}
+
+// Adds already hashed email to the struct
+func (ci CustomerInformation) WithHashedPhoneNumber(hashedPhoneNumber string) CustomerInformation {
+	ci.PhoneNumber = []string{hashedPhoneNumber}
+	return ci
+}
+
+// Adds first names to the struct for them to be normalized and hashed.
+func (ci CustomerInformation) WithFirstName(firstNames ...string) CustomerInformation {
+	ci.FirstName = hashAll(NormalizeName, firstNames)
+	return ci
+}
+
+// Adds last names to the struct for them to be normalized and hashed.
+func (ci CustomerInformation) WithLastName(lastNames ...string) CustomerInformation {
+	ci.LastName = hashAll(NormalizeName, lastNames)
+	return ci
+}
+
+// Adds dates of birth to the struct for them to be normalized and hashed, see NormalizeDateOfBirth for the accepted formats.
+func (ci CustomerInformation) WithDateOfBirth(datesOfBirth ...string) CustomerInformation {
+	ci.DateOfBirth = hashAll(NormalizeDateOfBirth, datesOfBirth)
+	return ci
+}
+
+// Adds genders to the struct for them to be normalized and hashed.
+func (ci CustomerInformation) WithGender(genders ...string) CustomerInformation {
+	ci.Gender = hashAll(NormalizeGender, genders)
+	return ci
+}
+
+// Adds cities to the struct for them to be normalized and hashed.
+func (ci CustomerInformation) WithCity(cities ...string) CustomerInformation {
+	ci.City = hashAll(NormalizeCity, cities)
+	return ci
+}
+
+// Adds states to the struct for them to be normalized and hashed.
+func (ci CustomerInformation) WithState(states ...string) CustomerInformation {
+	ci.State = hashAll(NormalizeState, states)
+	return ci
+}
+
+// Adds zip codes to the struct for them to be normalized and hashed.
+func (ci CustomerInformation) WithZip(zips ...string) CustomerInformation {
+	ci.Zip = hashAll(NormalizeZip, zips)
+	return ci
+}
+
+// Adds two letter country codes to the struct for them to be normalized and hashed.
+func (ci CustomerInformation) WithCountry(countries ...string) CustomerInformation {
+	ci.Country = hashAll(NormalizeCountry, countries)
+	return ci
+}
+
+// Adds external ids to the struct for them to be hashed.
+func (ci CustomerInformation) WithExternalID(externalIDs ...string) CustomerInformation {
+	ci.ExternalID = hashAll(NormalizeExternalID, externalIDs)
+	return ci
+}
+
+func (ci CustomerInformation) WithFbc(fbc string) CustomerInformation {
+	ci.Fbc = fbc
+	return ci
+}
+
+func (ci CustomerInformation) WithFbp(fbp string) CustomerInformation {
+	ci.Fbp = fbp
+	return ci
+}
+
+func (ci CustomerInformation) WithClientIPAddress(clientIPAddress string) CustomerInformation {
+	ci.ClientIPAddress = clientIPAddress
+	return ci
+}
+
+func (ci CustomerInformation) WithClientUserAgent(clientUserAgent string) CustomerInformation {
+	ci.ClientUserAgent = clientUserAgent
+	return ci
+}
+
+func (ci CustomerInformation) WithSubscriptionID(subscriptionID string) CustomerInformation {
+	ci.SubscriptionID = subscriptionID
+	return ci
+}
+
+// Adds the app scoped id of a user that logged in with Facebook Login, it is not hashed.
+func (ci CustomerInformation) WithFbLoginID(fbLoginID uint64) CustomerInformation {
+	ci.FbLoginID = fbLoginID
+	return ci
+}
+
+// Adds the id of a lead generated by lead ads, it is not hashed.
+func (ci CustomerInformation) WithLeadID(leadID uint64) CustomerInformation {
+	ci.LeadID = leadID
+	return ci
+}
+
+func (ci CustomerInformation) WithAnonID(anonID string) CustomerInformation {
+	ci.AnonID = anonID
+	return ci
+}
+
+func (ci CustomerInformation) WithMadID(madID string) CustomerInformation {
+	ci.MadID = madID
+	return ci
+}
+
+func (ci CustomerInformation) WithPageScopedUserID(pageID, pageScopedUserID string) CustomerInformation {
+	ci.PageID = pageID
+	ci.PageScopedUserID = pageScopedUserID
+	return ci
+}
+
+func (ci CustomerInformation) WithCtwaClid(ctwaClid string) CustomerInformation {
+	ci.CtwaClid = ctwaClid
+	return ci
+}
+
+func (ci CustomerInformation) WithInstagramScopedID(igAccountID, igSid string) CustomerInformation {
+	ci.IgAccountID = igAccountID
+	ci.IgSid = igSid
+	return ci
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCustomerInfoEmailHashing(t *testing.T) {
+	for _, td := range []struct {
+		in  string
+		out []string
+	}{
+		{"", nil},
+		{"test something", []string{"1f1f0339c99d3760514132f7a62b16906d8b431beeb40ba54841a447aa7be180"}},
+		{" Test@Example.COM ", []string{"973dfe463ec85785f5f95af5ba3906eedb2d931c24e69824a89ea65dba4e813b"}},
+		{"973DFE463EC85785F5F95AF5BA3906EEDB2D931C24E69824A89EA65DBA4E813B", []string{"973dfe463ec85785f5f95af5ba3906eedb2d931c24e69824a89ea65dba4e813b"}},
+	} {
+		t.Run(fmt.Sprintf("check hash for [%s]", td.in), func(t *testing.T) {
+			userData := NewCustomerInformation().WithEmail(td.in)
+			if fmt.Sprint(userData.Email) != fmt.Sprint(td.out) {
+				t.Fatalf("expected %v got %v", td.out, userData.Email)
+			}
+		})
+	}
+}
+
+func TestCustomerInfoPhoneNumberHashing(t *testing.T) {
+	userData := NewCustomerInformation().WithPhoneNumber("+49 (151) 123-45678", "0049 151 12345678", "n/a")
+	want := "8efb96404be52f6109b9db0f687274a81e9e3cc23fabd6f0da5d38baa3d2facd"
+	if len(userData.PhoneNumber) != 2 || userData.PhoneNumber[0] != want || userData.PhoneNumber[1] != want {
+		t.Fatalf("expected [%s %s] got %v", want, want, userData.PhoneNumber)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for _, td := range []struct {
+		name      string
+		normalize func(string) string
+		in        string
+		out       string
+	}{
+		{"email", NormalizeEmail, "  John.Doe@Example.com ", "john.doe@example.com"},
+		{"phone", NormalizePhone, "+1 (650) 555-1212", "16505551212"},
+		{"phone leading zeros", NormalizePhone, "0044 20 7946 0958", "442079460958"},
+		{"phone without digits", NormalizePhone, "unknown", ""},
+		{"name", NormalizeName, " Mary-Ann ", "maryann"},
+		{"name utf-8", NormalizeName, "Éloïse", "éloïse"},
+		{"city", NormalizeCity, "San Francisco", "sanfrancisco"},
+		{"city punctuation", NormalizeCity, "St. Louis", "stlouis"},
+		{"state code", NormalizeState, "CA", "ca"},
+		{"state name", NormalizeState, "New York", "ny"},
+		{"state non us", NormalizeState, "Baden-Württemberg", "badenwürttemberg"},
+		{"zip us", NormalizeZip, "94025", "94025"},
+		{"zip us plus four", NormalizeZip, "94025-1234", "94025"},
+		{"zip uk", NormalizeZip, "SW1A 1AA", "sw1a1aa"},
+		{"country", NormalizeCountry, " US ", "us"},
+		{"country invalid", NormalizeCountry, "Germany", ""},
+		{"date of birth", NormalizeDateOfBirth, "1970-02-01", "19700201"},
+		{"date of birth compact", NormalizeDateOfBirth, "19700201", "19700201"},
+		{"date of birth invalid", NormalizeDateOfBirth, "02/01/70", ""},
+		{"birth year", NormalizeBirthYear, " 1970", "1970"},
+		{"birth year invalid", NormalizeBirthYear, "70", ""},
+		{"gender", NormalizeGender, "Female", "f"},
+		{"gender short", NormalizeGender, " M", "m"},
+		{"gender unknown", NormalizeGender, "x", ""},
+		{"external id", NormalizeExternalID, " User-42 ", "User-42"},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			if got := td.normalize(td.in); got != td.out {
+				t.Fatalf("normalize(%q) = %q, want %q", td.in, got, td.out)
+			}
+		})
+	}
+}
+
+func TestIsHashed(t *testing.T) {
+	for _, td := range []struct {
+		in  string
+		out bool
+	}{
+		{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", true},
+		{"E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855", true},
+		{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b85", false},
+		{"z3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", false},
+		{"test@example.com", false},
+	} {
+		if got := IsHashed(td.in); got != td.out {
+			t.Fatalf("IsHashed(%q) = %v, want %v", td.in, got, td.out)
+		}
+	}
+}
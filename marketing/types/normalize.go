@@ -0,0 +1,192 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The normalizers below implement the formatting rules of the customer information parameters
+// https://developers.facebook.com/docs/marketing-api/conversions-api/parameters/customer-information-parameters
+// They return an empty string for values that are empty or invalid after normalization.
+
+// NormalizeEmail trims and lowercases an email address.
+func NormalizeEmail(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// NormalizePhone removes symbols, letters and leading zeros from a phone number.
+// The number has to include the country code.
+func NormalizePhone(s string) string {
+	return strings.TrimLeft(keepRunes(s, unicode.IsDigit), "0")
+}
+
+// NormalizeName lowercases a first or last name and removes punctuation.
+func NormalizeName(s string) string {
+	return strings.TrimSpace(strings.ToLower(keepRunes(s, func(r rune) bool {
+		return !unicode.IsPunct(r) && !unicode.IsSymbol(r)
+	})))
+}
+
+// NormalizeCity lowercases a city and removes punctuation, special characters and spaces.
+func NormalizeCity(s string) string {
+	return strings.ToLower(keepRunes(s, isAlphanumeric))
+}
+
+// NormalizeState returns the lowercase two letter code of US states, other states
+// are lowercased with punctuation, special characters and spaces removed.
+func NormalizeState(s string) string {
+	st := strings.ToLower(keepRunes(s, isAlphanumeric))
+	if code, ok := usStates[st]; ok {
+		return code
+	}
+
+	return st
+}
+
+// NormalizeZip lowercases a zip code and removes spaces and dashes.
+// US zip codes are shortened to their first five digits.
+func NormalizeZip(s string) string {
+	zp := strings.ToLower(keepRunes(s, isAlphanumeric))
+	if isUSZip(s) {
+		return zp[:5]
+	}
+
+	return zp
+}
+
+// NormalizeCountry returns a lowercase two letter ISO 3166-1 alpha-2 country code.
+func NormalizeCountry(s string) string {
+	c := strings.ToLower(keepRunes(s, unicode.IsLetter))
+	if len(c) != 2 {
+		return ""
+	}
+
+	return c
+}
+
+// dateOfBirthLayouts are the accepted input layouts, all normalized to YYYYMMDD.
+var dateOfBirthLayouts = []string{"20060102", "2006-01-02", "2006/01/02", "2006.01.02", time.RFC3339}
+
+// NormalizeDateOfBirth formats a date of birth as YYYYMMDD.
+func NormalizeDateOfBirth(s string) string {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateOfBirthLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t.Format("20060102")
+		}
+	}
+
+	return ""
+}
+
+// NormalizeBirthYear returns a four digit year of birth.
+func NormalizeBirthYear(s string) string {
+	y := keepRunes(s, unicode.IsDigit)
+	if len(y) != 4 || y < "1900" {
+		return ""
+	}
+
+	return y
+}
+
+// NormalizeGender returns "f" or "m".
+func NormalizeGender(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "f", "female":
+		return "f"
+	case "m", "male":
+		return "m"
+	}
+
+	return ""
+}
+
+// NormalizeExternalID trims an external id, its case is kept.
+func NormalizeExternalID(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// IsHashed returns whether s already is a hex encoded SHA-256 hash.
+func IsHashed(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+
+	return err == nil
+}
+
+// Hash normalizes s and returns its hex encoded SHA-256 hash. Values that already are
+// hashed are only lowercased, values that are empty after normalization return "".
+func Hash(normalize func(string) string, s string) string {
+	s = strings.TrimSpace(s)
+	if IsHashed(s) {
+		return strings.ToLower(s)
+	}
+
+	s = normalize(s)
+	if s == "" {
+		return ""
+	}
+	b := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(b[:])
+}
+
+// hashAll hashes all values and drops the ones that are empty after normalization.
+func hashAll(normalize func(string) string, values []string) []string {
+	var res []string
+	for _, v := range values {
+		h := Hash(normalize, v)
+		if h != "" {
+			res = append(res, h)
+		}
+	}
+
+	return res
+}
+
+func keepRunes(s string, keep func(rune) bool) string {
+	return strings.Map(func(r rune) rune {
+		if keep(r) {
+			return r
+		}
+
+		return -1
+	}, s)
+}
+
+func isAlphanumeric(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isUSZip returns whether s is formatted like a ZIP or ZIP+4 code.
+func isUSZip(s string) bool {
+	s = strings.TrimSpace(s)
+	switch len(s) {
+	case 5, 9:
+		return strings.TrimLeftFunc(s, unicode.IsDigit) == ""
+	case 10:
+		return s[5] == '-' && strings.TrimLeftFunc(s[:5]+s[6:], unicode.IsDigit) == ""
+	}
+
+	return false
+}
+
+var usStates = map[string]string{
+	"alabama": "al", "alaska": "ak", "arizona": "az", "arkansas": "ar", "california": "ca",
+	"colorado": "co", "connecticut": "ct", "delaware": "de", "districtofcolumbia": "dc", "florida": "fl",
+	"georgia": "ga", "hawaii": "hi", "idaho": "id", "illinois": "il", "indiana": "in",
+	"iowa": "ia", "kansas": "ks", "kentucky": "ky", "louisiana": "la", "maine": "me",
+	"maryland": "md", "massachusetts": "ma", "michigan": "mi", "minnesota": "mn", "mississippi": "ms",
+	"missouri": "mo", "montana": "mt", "nebraska": "ne", "nevada": "nv", "newhampshire": "nh",
+	"newjersey": "nj", "newmexico": "nm", "newyork": "ny", "northcarolina": "nc", "northdakota": "nd",
+	"ohio": "oh", "oklahoma": "ok", "oregon": "or", "pennsylvania": "pa", "rhodeisland": "ri",
+	"southcarolina": "sc", "southdakota": "sd", "tennessee": "tn", "texas": "tx", "utah": "ut",
+	"vermont": "vt", "virginia": "va", "washington": "wa", "westvirginia": "wv", "wisconsin": "wi",
+	"wyoming": "wy",
+}
@@ -0,0 +1,170 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxEventAge is how old an event may be when it is sent to the Conversions API.
+const MaxEventAge = 7 * 24 * time.Hour
+
+// MaxPhysicalStoreEventAge is how old an event with action_source physical_store may be.
+const MaxPhysicalStoreEventAge = 62 * 24 * time.Hour
+
+type UnixTime int64
+
+// Time returns ut as time.Time.
+func (ut UnixTime) Time() time.Time {
+	return time.Unix(int64(ut), 0)
+}
+
+// ServerEvent entity https://developers.facebook.com/docs/marketing-api/conversions-api/parameters/server-event
+type ServerEvent struct {
+	EventName      string              `json:"event_name"`
+	EventID        string              `json:"event_id,omitempty"`
+	EventTime      UnixTime            `json:"event_time"`
+	EventSourceURL string              `json:"event_source_url,omitempty"`
+	ActionSource   ActionSource        `json:"action_source"`
+	UserData       CustomerInformation `json:"user_data,omitempty"`
+	CustomData     *CustomData         `json:"custom_data,omitempty"`
+	AppData        *AppData            `json:"app_data,omitempty"`
+	OptOut         bool                `json:"opt_out,omitempty"`
+
+	// DataProcessingOptions enable Limited Data Use, see WithLimitedDataUse.
+	DataProcessingOptions        []string `json:"data_processing_options,omitempty"`
+	DataProcessingOptionsCountry *int     `json:"data_processing_options_country,omitempty"`
+	DataProcessingOptionsState   *int     `json:"data_processing_options_state,omitempty"`
+}
+
+// LimitedDataUse is the data processing option enabling Limited Data Use.
+const LimitedDataUse = "LDU"
+
+type ServerEvents []ServerEvent
+
+// MaxAge returns how old the event may be when it is sent, which depends on its action_source.
+func (e ServerEvent) MaxAge() time.Duration {
+	if e.ActionSource == PhysicalStore {
+		return MaxPhysicalStoreEventAge
+	}
+
+	return MaxEventAge
+}
+
+// Validate checks the parameters the Conversions API requires for an event sent at now.
+func (e ServerEvent) Validate(now time.Time) error {
+	if e.EventName == "" {
+		return errors.New("missing event_name")
+	} else if e.EventTime <= 0 {
+		return errors.New("missing event_time")
+	} else if e.ActionSource == "" {
+		return errors.New("missing action_source")
+	}
+
+	t := e.EventTime.Time()
+	if now.Sub(t) > e.MaxAge() {
+		return fmt.Errorf("event_time %s is older than %s", t.UTC().Format(time.RFC3339), e.MaxAge())
+	} else if t.After(now.Add(time.Minute)) {
+		return fmt.Errorf("event_time %s is in the future", t.UTC().Format(time.RFC3339))
+	}
+
+	if e.CustomData != nil {
+		err := e.CustomData.Validate(e.EventName)
+		if err != nil {
+			return fmt.Errorf("invalid custom_data: %w", err)
+		}
+	} else if e.EventName == "Purchase" {
+		return errors.New("custom_data with value and currency is required for Purchase events")
+	}
+
+	if e.AppData != nil {
+		err := e.AppData.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid app_data: %w", err)
+		}
+	} else if e.ActionSource == App {
+		return errors.New("app events require app_data")
+	}
+
+	for _, o := range e.DataProcessingOptions {
+		if o != LimitedDataUse {
+			return fmt.Errorf("invalid data processing option '%s'", o)
+		}
+	}
+	if len(e.DataProcessingOptions) == 0 && (e.DataProcessingOptionsCountry != nil || e.DataProcessingOptionsState != nil) {
+		return errors.New("data_processing_options_country and _state require data_processing_options")
+	}
+
+	if e.ActionSource == Website {
+		if e.EventSourceURL == "" {
+			return errors.New("website events require event_source_url")
+		} else if e.UserData.ClientUserAgent == "" {
+			return errors.New("website events require client_user_agent")
+		}
+	}
+
+	return nil
+}
+
+func NewServerEvent(eventName, eventId string, eventTime UnixTime, actionSource ActionSource) ServerEvent {
+	return ServerEvent{
+		EventName:    eventName,
+		EventID:      eventId,
+		EventTime:    eventTime,
+		ActionSource: actionSource,
+	}
+}
+
+func (e ServerEvent) WithEventSourceURL(eventSourceURL string) ServerEvent {
+	e.EventSourceURL = eventSourceURL
+	return e
+}
+
+func (e ServerEvent) WithCustomData(customData CustomData) ServerEvent {
+	e.CustomData = &customData
+	return e
+}
+
+func (e ServerEvent) WithAppData(appData AppData) ServerEvent {
+	e.AppData = &appData
+	return e
+}
+
+func (e ServerEvent) WithUserData(customerInfo CustomerInformation) ServerEvent {
+	e.UserData = customerInfo
+	return e
+}
+
+// WithContents sets the contents of the custom data.
+func (e ServerEvent) WithContents(contents Contents) ServerEvent {
+	cd := CustomData{}
+	if e.CustomData != nil {
+		cd = *e.CustomData
+	}
+	cd = cd.WithContents(contents)
+	e.CustomData = &cd
+	return e
+}
+
+// WithLimitedDataUse enables Limited Data Use. Pass 0 as country and state to let Meta geolocate the event,
+// or 1 and the state code (e.g. 1000 for California) for the United States.
+func (e ServerEvent) WithLimitedDataUse(country, state int) ServerEvent {
+	e.DataProcessingOptions = []string{LimitedDataUse}
+	e.DataProcessingOptionsCountry = &country
+	e.DataProcessingOptionsState = &state
+	return e
+}
+
+type ActionSource string
+
+const (
+	Email             ActionSource = "email"
+	Website           ActionSource = "website"
+	App               ActionSource = "app"
+	PhoneCall         ActionSource = "phone_call"
+	Chat              ActionSource = "chat"
+	PhysicalStore     ActionSource = "physical_store"
+	SystemGenerated   ActionSource = "system_generated"
+	BusinessMessaging ActionSource = "business_messaging"
+	Other             ActionSource = "other"
+)
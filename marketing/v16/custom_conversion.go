@@ -8,7 +8,7 @@ import (
 	"net/url"
 
 	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
-	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/v16/types"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
 )
 
 // CustomConversionService contains all methods for working on custom conversions.
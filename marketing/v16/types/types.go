@@ -0,0 +1,42 @@
+// Package types is kept for compatibility, the server event types moved to marketing/types.
+//
+// Deprecated: use the marketing/types package.
+package types
+
+import (
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+type (
+	UnixTime            = types.UnixTime
+	ServerEvent         = types.ServerEvent
+	ServerEvents        = types.ServerEvents
+	ActionSource        = types.ActionSource
+	CustomerInformation = types.CustomerInformation
+	Contents            = types.Contents
+	Content             = types.Content
+)
+
+const (
+	Email             = types.Email
+	Website           = types.Website
+	App               = types.App
+	PhoneCall         = types.PhoneCall
+	Chat              = types.Chat
+	PhysicalStore     = types.PhysicalStore
+	SystemGenerated   = types.SystemGenerated
+	BusinessMessaging = types.BusinessMessaging
+	Other             = types.Other
+)
+
+func NewServerEvent(eventName, eventId string, eventTime UnixTime, actionSource ActionSource) ServerEvent {
+	return types.NewServerEvent(eventName, eventId, eventTime, actionSource)
+}
+
+func NewCustomerInformation() CustomerInformation {
+	return types.NewCustomerInformation()
+}
+
+func NewContents() Contents {
+	return types.NewContents()
+}
@@ -1,3 +1,6 @@
+// Package v19 is a frozen copy of the services for graph API v19.0, which Meta has retired.
+//
+// Deprecated: use the marketing package, which takes the graph API version as a parameter.
 package v19
 
 import (
@@ -1,10 +1,15 @@
 package v22_test
 
 import (
+	"context"
+	"io"
+	"net/http"
 	"net/url"
 	"strings"
 	"testing"
 
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
 	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/v22"
 )
 
@@ -60,3 +65,44 @@ func TestList_usesDefaultStatuses(t *testing.T) {
 		t.Fatalf("defaults mismatch\n got: %s\nwant: %s", got, exp)
 	}
 }
+
+func TestCampaignCreate_omitsEmptyFields(t *testing.T) {
+	var body string
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		res := `{}`
+		if request.Method == http.MethodPost {
+			b, err := io.ReadAll(request.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body = strings.TrimSpace(string(b))
+			res = `{"id":"c1"}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(res)),
+			Request:    request,
+		}, nil
+	})}
+	s, err := v22.NewWithClient(log.NewNopLogger(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Campaigns.Create(context.Background(), v22.Campaign{AccountID: "1", Name: "c", Objective: "OUTCOME_SALES"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, `"name":"c"`) || strings.Contains(body, "is_adset_budget_sharing_enabled") || strings.Contains(body, "special_ad_categories") {
+		t.Fatalf("unexpected v22.0 campaign body %s", body)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
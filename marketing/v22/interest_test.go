@@ -0,0 +1,45 @@
+package v22_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/v22"
+)
+
+func TestTargetingSearch_v22Signature(t *testing.T) {
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		res := `{}`
+		if request.URL.Path == "/v22.0/act_1/targetingsearch" {
+			if q := request.URL.Query(); q.Get("q") != "golf" || q.Has("limit_type") {
+				t.Fatalf("unexpected query %s", request.URL.RawQuery)
+			}
+			res = `{"data":[{"id":"6003","name":"Golf","type":"interests"}]}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(res)),
+			Request:    request,
+		}, nil
+	})}
+	s, err := v22.NewWithClient(log.NewNopLogger(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Interests.TargetingSearch(context.Background(), "1", "golf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].Name != "Golf" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
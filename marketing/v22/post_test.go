@@ -0,0 +1,46 @@
+package v22_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/v22"
+)
+
+func TestPostGet_linkAttachmentIsStatus(t *testing.T) {
+	client := fb.NewClient(log.NewNopLogger(), "token", "")
+	client.Client = &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		res := `{}`
+		switch request.URL.Path {
+		case "/v22.0/1_2":
+			res = `{"id":"1_2","message":"hello"}`
+		case "/v22.0/1_2/attachments":
+			res = `{"data":[{"media_type":"link","type":"share","url":"https://example.com"}]}`
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Body:       io.NopCloser(strings.NewReader(res)),
+			Request:    request,
+		}, nil
+	})}
+	s, err := v22.NewWithClient(log.NewNopLogger(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := s.Posts.Get(context.Background(), "1_2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// status posts are not among the types returned by Get in v22.0
+	if p.Type != "unknown" || p.Link != "https://example.com" {
+		t.Fatalf("unexpected post %+v", p)
+	}
+}
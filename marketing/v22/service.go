@@ -1,73 +1,120 @@
+// Package v22 pins the marketing services to graph API v22.0.
 package v22
 
 import (
-	"context"
-	"errors"
-
 	"github.com/go-kit/log"
 	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing"
 )
 
 // Version of the graph API being used.
 const Version = "v22.0"
 
-// Service interacts with the Facebook Marketing API.
-type Service struct {
-	*fb.Client
-	AdAccounts        *AdAccountService
-	AdCreatives       *AdCreativeService
-	Adsets            *AdsetService
-	Ads               *AdService
-	Audiences         *AudienceService
-	Campaigns         *CampaignService
-	CustomConversions *CustomConversionService
-	Events            *EventService
-	Insights          *InsightsService
-	Interests         *InterestService
-	Images            *ImageService
-	Pages             *PageService
-	Posts             *PostService
-	Search            *SearchService
-	Videos            *VideoService
+func init() {
+	marketing.RegisterVersion(marketing.Version{
+		Name:             Version,
+		CampaignFields:   campaignFields,
+		AdsetFields:      AdsetFields,
+		AdCreativeFields: Adcreativefields,
+		// v22.0 campaigns are written without budget sharing and without empty special ad categories.
+		OmitEmptyCampaignFields: true,
+		// v22.0 reports posts with link attachments as status posts.
+		LinkPostsAsStatus: true,
+	})
 }
 
+// Service interacts with the Facebook Marketing API.
+type Service = marketing.Service
+
 // New initializes a new Service and all the Services contained.
 func New(l log.Logger, accessToken, appSecret string) (*Service, error) {
-	c := fb.NewClient(l, accessToken, appSecret)
-	err := c.GetJSON(context.Background(), fb.NewRoute(Version, "/me").String(), &struct{}{})
-	if err != nil {
-		return nil, err
-	}
+	return marketing.New(l, accessToken, appSecret, Version)
+}
 
-	return &Service{
-		Client:            c,
-		AdAccounts:        &AdAccountService{c},
-		AdCreatives:       &AdCreativeService{c, fb.NewStatsContainer()},
-		Adsets:            &AdsetService{c},
-		Ads:               &AdService{c},
-		Audiences:         &AudienceService{c},
-		Campaigns:         &CampaignService{c},
-		CustomConversions: &CustomConversionService{c},
-		Events:            &EventService{c},
-		Insights:          newInsightsService(l, c),
-		Interests:         &InterestService{c},
-		Images:            &ImageService{c},
-		Pages:             &PageService{c},
-		Posts:             &PostService{c, fb.NewStatsContainer()},
-		Search:            &SearchService{c},
-		Videos:            &VideoService{c},
-	}, nil
+// NewWithConfig initializes a new Service with the given rate-limit configuration.
+func NewWithConfig(l log.Logger, accessToken, appSecret string, cfg fb.RateLimitConfig) (*Service, error) {
+	return marketing.NewWithConfig(l, accessToken, appSecret, Version, cfg)
 }
 
-// GetMetadata returns the metadata of a graph API object.
-func (s *Service) GetMetadata(ctx context.Context, id string) (*fb.Metadata, error) {
-	res := &fb.MetadataContainer{}
-	err := s.Client.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Metadata(true).String(), res)
-	if err != nil {
-		return nil, err
-	} else if res.Metadata == nil {
-		return nil, errors.New("could not get metadata")
-	}
+// NewWithClient initializes a new Service using a pre-configured fb.Client.
+// The client is validated by making a /me call.
+func NewWithClient(l log.Logger, c *fb.Client) (*Service, error) {
+	return marketing.NewWithClient(l, c, Version)
+}
+
+// campaignFields is the v22.0 selection of campaign fields.
+var campaignFields = []string{
+	"id",
+	"account_id",
+	"adlabels",
+	"bid_strategy",
+	"boosted_object_id",
+	"brand_lift_studies",
+	"budget_rebalance_flag",
+	"budget_remaining",
+	"buying_type",
+	"can_create_brand_lift_study",
+	"can_use_spend_cap",
+	"configured_status",
+	"created_time",
+	"daily_budget",
+	"effective_status",
+	"lifetime_budget",
+	"name",
+	"objective",
+	"recommendations",
+	"source_campaign",
+	"source_campaign_id",
+	"spend_cap",
+	"start_time",
+	"status",
+	"stop_time",
+	"updated_time",
+}
+
+// AdsetFields is a selection of fields to be returned.
+var AdsetFields = []string{
+	"bid_amount", "attribution_spec", "bid_info",
+	"billing_event", "campaign_id", "created_time",
+	"daily_budget", "destination_type", "effective_status",
+	"daily_spend_cap", "daily_min_spend_target", "end_time",
+	"creative_sequence", "frequency_control_specs", "id",
+	"configured_status", "instagram_user_id", "lifetime_budget",
+	"lifetime_imps", "lifetime_min_spend_target", "lifetime_spend_cap",
+	"name", "budget_remaining", "optimization_goal", "adset_schedule",
+	"adlabels", "recurring_budget_semantics",
+	"rf_prediction_id", "source_adset_id", "start_time", "targeting",
+	"time_based_ad_rotation_id_blocks", "time_based_ad_rotation_intervals",
+	"pacing_type", "promoted_object", "recommendations",
+	"source_adset", "status", "updated_time", "use_new_app_click",
+	"campaign{name,objective,effective_status}", "dsa_beneficiary", "dsa_payor",
+}
 
-	return res.Metadata, nil
+// Adcreativefields are the fields of an adcreative.
+var Adcreativefields = []string{
+	"id",
+	"account_id",
+	"body", // The body of the ad. Not supported for video post creatives.
+	"call_to_action_type",
+	"effective_instagram_media_id",
+	"effective_object_story_id",
+	"image_hash",
+	"image_url",
+	"instagram_user_id",
+	"instagram_permalink_url",
+	"source_instagram_media_id",
+	"link_og_id",
+	"link_url",
+	"name",
+	"interactive_components_spec",
+	"object_id",
+	"object_story_id",
+	"object_story_spec",
+	"object_type",
+	"object_url",
+	"status",
+	"thumbnail_url",
+	"title",
+	"video_id",
+	"asset_feed_spec",
 }
@@ -1,83 +1,34 @@
+// Package v24 pins the marketing services to graph API v24.0.
 package v24
 
 import (
-	"context"
-	"errors"
-
 	"github.com/go-kit/log"
 	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing"
 )
 
 // Version of the graph API being used.
 const Version = "v24.0"
 
-// Service interacts with the Facebook Marketing API.
-type Service struct {
-	*fb.Client
-	AdAccounts        *AdAccountService
-	AdCreatives       *AdCreativeService
-	Adsets            *AdsetService
-	Ads               *AdService
-	Audiences         *AudienceService
-	Campaigns         *CampaignService
-	CustomConversions *CustomConversionService
-	Events            *EventService
-	Insights          *InsightsService
-	Interests         *InterestService
-	Images            *ImageService
-	Pages             *PageService
-	Posts             *PostService
-	Search            *SearchService
-	Videos            *VideoService
+func init() {
+	marketing.RegisterVersion(marketing.Version{Name: Version})
 }
 
+// Service interacts with the Facebook Marketing API.
+type Service = marketing.Service
+
 // New initializes a new Service and all the Services contained.
 func New(l log.Logger, accessToken, appSecret string) (*Service, error) {
-	return NewWithClient(l, fb.NewClient(l, accessToken, appSecret))
+	return marketing.New(l, accessToken, appSecret, Version)
 }
 
 // NewWithConfig initializes a new Service with the given rate-limit configuration.
 func NewWithConfig(l log.Logger, accessToken, appSecret string, cfg fb.RateLimitConfig) (*Service, error) {
-	return NewWithClient(l, fb.NewClientWithConfig(l, accessToken, appSecret, cfg))
+	return marketing.NewWithConfig(l, accessToken, appSecret, Version, cfg)
 }
 
 // NewWithClient initializes a new Service using a pre-configured fb.Client.
 // The client is validated by making a /me call.
 func NewWithClient(l log.Logger, c *fb.Client) (*Service, error) {
-	err := c.GetJSON(context.Background(), fb.NewRoute(Version, "/me").String(), &struct{}{})
-	if err != nil {
-		return nil, err
-	}
-
-	return &Service{
-		Client:            c,
-		AdAccounts:        &AdAccountService{c},
-		AdCreatives:       &AdCreativeService{c, fb.NewStatsContainer()},
-		Adsets:            &AdsetService{c},
-		Ads:               &AdService{c},
-		Audiences:         &AudienceService{c},
-		Campaigns:         &CampaignService{c},
-		CustomConversions: &CustomConversionService{c},
-		Events:            &EventService{c},
-		Insights:          newInsightsService(l, c),
-		Interests:         &InterestService{c},
-		Images:            &ImageService{c},
-		Pages:             &PageService{c},
-		Posts:             &PostService{c, fb.NewStatsContainer()},
-		Search:            &SearchService{c},
-		Videos:            &VideoService{c},
-	}, nil
-}
-
-// GetMetadata returns the metadata of a graph API object.
-func (s *Service) GetMetadata(ctx context.Context, id string) (*fb.Metadata, error) {
-	res := &fb.MetadataContainer{}
-	err := s.Client.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Metadata(true).String(), res)
-	if err != nil {
-		return nil, err
-	} else if res.Metadata == nil {
-		return nil, errors.New("could not get metadata")
-	}
-
-	return res.Metadata, nil
+	return marketing.NewWithClient(l, c, Version)
 }
@@ -0,0 +1,154 @@
+package v24
+
+import (
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing"
+)
+
+// The types below are shared by all graph API versions and live in the marketing package.
+type (
+	ActionTypeValue                     = marketing.ActionTypeValue
+	Ad                                  = marketing.Ad
+	AdAccount                           = marketing.AdAccount
+	AdAccountService                    = marketing.AdAccountService
+	AdCreative                          = marketing.AdCreative
+	AdCreativeLinkData                  = marketing.AdCreativeLinkData
+	AdCreativeLinkDataAppLinkSpec       = marketing.AdCreativeLinkDataAppLinkSpec
+	AdCreativeLinkDataCallToAction      = marketing.AdCreativeLinkDataCallToAction
+	AdCreativeLinkDataCallToActionValue = marketing.AdCreativeLinkDataCallToActionValue
+	AdCreativeLinkDataChildAttachment   = marketing.AdCreativeLinkDataChildAttachment
+	AdCreativeListCall                  = marketing.AdCreativeListCall
+	AdCreativePhotoData                 = marketing.AdCreativePhotoData
+	AdCreativeService                   = marketing.AdCreativeService
+	AdGeoLocation                       = marketing.AdGeoLocation
+	AdListCall                          = marketing.AdListCall
+	AdLocale                            = marketing.AdLocale
+	AdService                           = marketing.AdService
+	Adaccounts                          = marketing.Adaccounts
+	Adset                               = marketing.Adset
+	AdsetListCall                       = marketing.AdsetListCall
+	AdsetService                        = marketing.AdsetService
+	AdvantageStateInfo                  = marketing.AdvantageStateInfo
+	AndroidAppLink                      = marketing.AndroidAppLink
+	AssetCustomizationRule              = marketing.AssetCustomizationRule
+	AssetFeedSpec                       = marketing.AssetFeedSpec
+	AssetFeedSpecImage                  = marketing.AssetFeedSpecImage
+	AssetFeedSpecLinkURL                = marketing.AssetFeedSpecLinkURL
+	AssetFeedSpecTextAsset              = marketing.AssetFeedSpecTextAsset
+	AssetFeedSpecVideo                  = marketing.AssetFeedSpecVideo
+	AssetLabel                          = marketing.AssetLabel
+	AudienceService                     = marketing.AudienceService
+	AudienceSize                        = marketing.AudienceSize
+	Business                            = marketing.Business
+	Campaign                            = marketing.Campaign
+	CampaignListCall                    = marketing.CampaignListCall
+	CampaignService                     = marketing.CampaignService
+	City                                = marketing.City
+	Comment                             = marketing.Comment
+	CommentListCall                     = marketing.CommentListCall
+	Component                           = marketing.Component
+	ConversionActionQuery               = marketing.ConversionActionQuery
+	CreativeFeaturesSpec                = marketing.CreativeFeaturesSpec
+	CustomAudience                      = marketing.CustomAudience
+	CustomConversion                    = marketing.CustomConversion
+	CustomConversionService             = marketing.CustomConversionService
+	DailyOutcomesCurve                  = marketing.DailyOutcomesCurve
+	Data                                = marketing.Data
+	DataSource                          = marketing.DataSource
+	DegreesOfFreedomSpec                = marketing.DegreesOfFreedomSpec
+	DeliveryEstimate                    = marketing.DeliveryEstimate
+	Device                              = marketing.Device
+	EffectiveStatus                     = marketing.EffectiveStatus
+	EnrollStatus                        = marketing.EnrollStatus
+	EventService                        = marketing.EventService
+	FlexibleSpec                        = marketing.FlexibleSpec
+	FrequencyControlSpec                = marketing.FrequencyControlSpec
+	GeoLocation                         = marketing.GeoLocation
+	GeoLocations                        = marketing.GeoLocations
+	IDContainer                         = marketing.IDContainer
+	Image                               = marketing.Image
+	ImageService                        = marketing.ImageService
+	IndividualSetting                   = marketing.IndividualSetting
+	Insight                             = marketing.Insight
+	InsightsRequest                     = marketing.InsightsRequest
+	InsightsService                     = marketing.InsightsService
+	InstagramComment                    = marketing.InstagramComment
+	InstagramPost                       = marketing.InstagramPost
+	InstagramPostListCall               = marketing.InstagramPostListCall
+	InstagramUser                       = marketing.InstagramUser
+	InteractiveComponent                = marketing.InteractiveComponent
+	InteractiveComponentsSpec           = marketing.InteractiveComponentsSpec
+	InterestService                     = marketing.InterestService
+	InterestTargeting                   = marketing.InterestTargeting
+	IosAppLink                          = marketing.IosAppLink
+	LocationSpec                        = marketing.LocationSpec
+	LookalikeOrigion                    = marketing.LookalikeOrigion
+	LookalikeSpec                       = marketing.LookalikeSpec
+	MessageTag                          = marketing.MessageTag
+	ObjectStorySpec                     = marketing.ObjectStorySpec
+	OperatingSystem                     = marketing.OperatingSystem
+	OptionCallToAction                  = marketing.OptionCallToAction
+	OptionCallToActionValue             = marketing.OptionCallToActionValue
+	Page                                = marketing.Page
+	PageService                         = marketing.PageService
+	Pixel                               = marketing.Pixel
+	PlacementCustomizationSpec          = marketing.PlacementCustomizationSpec
+	PlacementSoftOptOut                 = marketing.PlacementSoftOptOut
+	PollSpec                            = marketing.PollSpec
+	PositionSpec                        = marketing.PositionSpec
+	Positions                           = marketing.Positions
+	Post                                = marketing.Post
+	PostListCall                        = marketing.PostListCall
+	PostService                         = marketing.PostService
+	PromotedObject                      = marketing.PromotedObject
+	ReachEstimate                       = marketing.ReachEstimate
+	ReachEstimateData                   = marketing.ReachEstimateData
+	Reactions                           = marketing.Reactions
+	Region                              = marketing.Region
+	SearchService                       = marketing.SearchService
+	SharedAccountInfo                   = marketing.SharedAccountInfo
+	StoryAttachment                     = marketing.StoryAttachment
+	StoryAttachmentMedia                = marketing.StoryAttachmentMedia
+	StoryAttachmentTarget               = marketing.StoryAttachmentTarget
+	StoryAttachments                    = marketing.StoryAttachments
+	Targeting                           = marketing.Targeting
+	TargetingAutomation                 = marketing.TargetingAutomation
+	TargetingOptionStatus               = marketing.TargetingOptionStatus
+	TargetingRelaxationTypes            = marketing.TargetingRelaxationTypes
+	TargetingValidationResult           = marketing.TargetingValidationResult
+	UploadError                         = marketing.UploadError
+	User                                = marketing.User
+	Video                               = marketing.Video
+	VideoData                           = marketing.VideoData
+	VideoService                        = marketing.VideoService
+	VideoThumbnail                      = marketing.VideoThumbnail
+	Zip                                 = marketing.Zip
+)
+
+const (
+	EffectiveStatusActive             = marketing.EffectiveStatusActive
+	EffectiveStatusPaused             = marketing.EffectiveStatusPaused
+	EffectiveStatusDeleted            = marketing.EffectiveStatusDeleted
+	EffectiveStatusPendingReview      = marketing.EffectiveStatusPendingReview
+	EffectiveStatusDisapproved        = marketing.EffectiveStatusDisapproved
+	EffectiveStatusPreApproved        = marketing.EffectiveStatusPreApproved
+	EffectiveStatusPendingBillingInfo = marketing.EffectiveStatusPendingBillingInfo
+	EffectiveStatusCampaignPaused     = marketing.EffectiveStatusCampaignPaused
+	EffectiveStatusArchived           = marketing.EffectiveStatusArchived
+	EffectiveStatusAdsetPaused        = marketing.EffectiveStatusAdsetPaused
+
+	OPT_OUT = marketing.OPT_OUT
+	OPT_IN  = marketing.OPT_IN
+
+	// BatchMaxIDsSequence we upload.
+	BatchMaxIDsSequence = marketing.BatchMaxIDsSequence
+)
+
+var (
+	// AdsetFields is a selection of fields to be returned.
+	AdsetFields = marketing.AdsetFields
+	// Adcreativefields are the fields of an adcreative.
+	Adcreativefields = marketing.Adcreativefields
+	// DefaultEffectiveStatuses is the default set of effective_status values
+	// returned by the CampaignService.List call.
+	DefaultEffectiveStatuses = marketing.DefaultEffectiveStatuses
+)
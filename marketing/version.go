@@ -0,0 +1,107 @@
+package marketing
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// LatestVersion is the newest graph API version the services in this package are modelled after.
+const LatestVersion = "v24.0"
+
+// Version selects the graph API version a Service talks to. The field selections
+// only need to be set when a version does not know all the fields requested by
+// default, e.g. fields that were introduced after it.
+type Version struct {
+	// Name is the version path segment, e.g. "v24.0".
+	Name string
+	// CampaignFields replaces the default fields requested by CampaignService.Get.
+	CampaignFields []string
+	// AdsetFields replaces AdsetFields as default selection of the AdsetService.
+	AdsetFields []string
+	// AdCreativeFields replaces Adcreativefields as default selection of the AdCreativeService.
+	AdCreativeFields []string
+	// OmitEmptyCampaignFields leaves is_adset_budget_sharing_enabled and empty special_ad_categories
+	// out of campaign writes, like the campaign payload of v22.0 did.
+	OmitEmptyCampaignFields bool
+	// LinkPostsAsStatus reports posts with link attachments as type "status", like the PostService of v22.0 did.
+	LinkPostsAsStatus bool
+}
+
+func (v Version) campaignFields() []string {
+	if len(v.CampaignFields) > 0 {
+		return v.CampaignFields
+	}
+
+	return campaignFields
+}
+
+func (v Version) adsetFields() []string {
+	if len(v.AdsetFields) > 0 {
+		return v.AdsetFields
+	}
+
+	return AdsetFields
+}
+
+func (v Version) adCreativeFields() []string {
+	if len(v.AdCreativeFields) > 0 {
+		return v.AdCreativeFields
+	}
+
+	return Adcreativefields
+}
+
+// campaignPayload returns the body of campaign writes.
+func (v Version) campaignPayload(c Campaign) (interface{}, error) {
+	if !v.OmitEmptyCampaignFields {
+		return c, nil
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]json.RawMessage{}
+	err = json.Unmarshal(b, &payload)
+	if err != nil {
+		return nil, err
+	}
+	delete(payload, "is_adset_budget_sharing_enabled")
+	if len(c.SpecialAdCategories) == 0 {
+		delete(payload, "special_ad_categories")
+	}
+
+	return payload, nil
+}
+
+var (
+	versionsMu sync.RWMutex
+	// versions contains LatestVersion, whose field selections are the defaults of this package.
+	versions = map[string]Version{LatestVersion: {Name: LatestVersion}}
+)
+
+// RegisterVersion makes the field selections of v available to New and LookupVersion.
+// The version packages, e.g. marketing/v22, register themselves when imported.
+func RegisterVersion(v Version) {
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+	versions[v.Name] = v
+}
+
+// LookupVersion returns the registered Version called name, an empty name selects LatestVersion.
+// Other versions are only known after their package, e.g. marketing/v22, was imported.
+func LookupVersion(name string) (Version, error) {
+	if name == "" {
+		name = LatestVersion
+	}
+
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+	v, ok := versions[name]
+	if !ok {
+		return Version{}, fmt.Errorf("graph API version '%s' is not registered, import its package (e.g. marketing/v22)", name)
+	}
+
+	return v, nil
+}
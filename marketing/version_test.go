@@ -0,0 +1,41 @@
+package marketing
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestLookupVersion(t *testing.T) {
+	RegisterVersion(Version{Name: "v1.0", CampaignFields: []string{"id", "name"}})
+
+	v, err := LookupVersion("v1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.campaignFields(); !reflect.DeepEqual(got, []string{"id", "name"}) {
+		t.Fatalf("registered campaign fields = %v, want [id name]", got)
+	}
+	v, err = LookupVersion("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != LatestVersion || !reflect.DeepEqual(v.campaignFields(), campaignFields) {
+		t.Fatalf("empty version = %+v, want %s with default fields", v, LatestVersion)
+	}
+	if _, err := LookupVersion("v2.0"); err == nil {
+		t.Fatal("expected error for unregistered version")
+	}
+}
+
+func TestServicesUseVersion(t *testing.T) {
+	cs := &CampaignService{v: Version{Name: "v23.0"}}
+
+	u, err := url.Parse(cs.List("123").String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Path != "/v23.0/act_123/campaigns" {
+		t.Fatalf("path = %q, want %q", u.Path, "/v23.0/act_123/campaigns")
+	}
+}
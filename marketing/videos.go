@@ -1,4 +1,4 @@
-package v24
+package marketing
 
 import (
 	"context"
@@ -13,12 +13,13 @@ import (
 // VideoService works with advideos.
 type VideoService struct {
 	c *fb.Client
+	v Version
 }
 
 // Get returns a single Video.
 func (vs *VideoService) Get(ctx context.Context, id string) (*Video, error) {
 	res := &Video{}
-	err := vs.c.GetJSON(ctx, fb.NewRoute(Version, "/%s", id).Fields(advideoFields...).String(), res)
+	err := vs.c.GetJSON(ctx, fb.NewRoute(vs.v.Name, "/%s", id).Fields(advideoFields...).String(), res)
 	if err != nil {
 		if fb.IsNotFound(err) {
 			return nil, nil
@@ -71,7 +72,7 @@ func (vs *VideoService) UploadWithoutFetch(ctx context.Context, act, title strin
 // returns the resulting video ID. Meta returns video_id in the start-phase
 // response, so we already have it before the chunked transfer even begins.
 func (vs *VideoService) uploadChunks(ctx context.Context, act, title string, size int64, r io.Reader) (string, error) {
-	url := fb.NewRoute(Version, "/act_%s/advideos", act).String()
+	url := fb.NewRoute(vs.v.Name, "/act_%s/advideos", act).String()
 
 	res := uploadVideoResponse{}
 	err := vs.c.PostJSON(ctx, url, uploadVideoRequestStart{
@@ -118,7 +119,7 @@ func (vs *VideoService) ReadList(ctx context.Context, act string, res chan<- Vid
 	wg.Go(func() error {
 		defer close(jres)
 
-		return vs.c.ReadList(ctx, fb.NewRoute(Version, "/act_%s/advideos", act).Fields(advideoFields...).Limit(200).String(), jres)
+		return vs.c.ReadList(ctx, fb.NewRoute(vs.v.Name, "/act_%s/advideos", act).Fields(advideoFields...).Limit(200).String(), jres)
 	})
 	wg.Go(func() error {
 		for e := range jres {
@@ -144,7 +145,7 @@ func (vs *VideoService) Thumbnails(ctx context.Context, videoID string) ([]Video
 	res := &videoThumbnailsResponse{}
 	err := vs.c.GetJSON(
 		ctx,
-		fb.NewRoute(Version, "/%s/thumbnails", videoID).Fields(videoThumbnailFields...).String(),
+		fb.NewRoute(vs.v.Name, "/%s/thumbnails", videoID).Fields(videoThumbnailFields...).String(),
 		res,
 	)
 	if err != nil {
@@ -0,0 +1,256 @@
+// Package tracking extracts the browser parameters of Conversions API server events from HTTP requests.
+package tracking
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+const (
+	fbpCookie    = "_fbp"
+	fbcCookie    = "_fbc"
+	fbclidParam  = "fbclid"
+	fbcLifetime  = 90 * 24 * time.Hour
+	cookieFormat = "fb.%d.%d.%s"
+)
+
+// Info contains the parameters of a server event that are only known while handling the request.
+type Info struct {
+	ClientIPAddress string
+	ClientUserAgent string
+	Fbp             string
+	Fbc             string
+	EventSourceURL  string
+	// EventID is generated for every request, pass it to the browser pixel as eventID
+	// so Meta can deduplicate the browser and server event.
+	EventID string
+	// fbcCreated is set if Fbc was created from the fbclid query parameter.
+	fbcCreated bool
+}
+
+// CustomerInformation returns the customer information parameters of Info.
+func (i Info) CustomerInformation() types.CustomerInformation {
+	return types.NewCustomerInformation().
+		WithClientIPAddress(i.ClientIPAddress).
+		WithClientUserAgent(i.ClientUserAgent).
+		WithFbp(i.Fbp).
+		WithFbc(i.Fbc)
+}
+
+// Apply sets the non empty browser parameters and, if they are empty, the event source url and the event id of e.
+func (i Info) Apply(e types.ServerEvent) types.ServerEvent {
+	if e.EventID == "" {
+		e.EventID = i.EventID
+	}
+	if e.EventSourceURL == "" {
+		e.EventSourceURL = i.EventSourceURL
+	}
+	if i.ClientIPAddress != "" {
+		e.UserData.ClientIPAddress = i.ClientIPAddress
+	}
+	if i.ClientUserAgent != "" {
+		e.UserData.ClientUserAgent = i.ClientUserAgent
+	}
+	if i.Fbp != "" {
+		e.UserData.Fbp = i.Fbp
+	}
+	if i.Fbc != "" {
+		e.UserData.Fbc = i.Fbc
+	}
+
+	return e
+}
+
+// Extractor extracts Info from requests.
+type Extractor struct {
+	// ProxyHeaders contain the client ip, e.g. X-Forwarded-For or X-Real-IP. They are checked in
+	// order and only honoured for requests from TrustedProxies, so they are ignored if TrustedProxies
+	// is empty. Trusting 0.0.0.0/0 and ::/0 honours them for any peer.
+	ProxyHeaders []string
+	// TrustedProxies are skipped when reading the client ip from the end of a proxy header.
+	TrustedProxies []*net.IPNet
+	// CookieDomain is the domain of the _fbc cookie the middleware sets when it creates fbc from fbclid,
+	// no cookie is set if it is empty.
+	CookieDomain string
+
+	now func() time.Time
+}
+
+// New returns an Extractor honouring the proxy headers for requests from the trusted proxies,
+// given as IPs or CIDRs.
+func New(proxyHeaders []string, trustedProxies ...string) (*Extractor, error) {
+	e := &Extractor{ProxyHeaders: proxyHeaders}
+	for _, p := range trustedProxies {
+		if !strings.Contains(p, "/") {
+			if strings.Contains(p, ":") {
+				p += "/128"
+			} else {
+				p += "/32"
+			}
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %w", err)
+		}
+		e.TrustedProxies = append(e.TrustedProxies, n)
+	}
+
+	return e, nil
+}
+
+// Extract returns the Info of r with a new event id.
+func (e *Extractor) Extract(r *http.Request) Info {
+	now := time.Now()
+	if e.now != nil {
+		now = e.now()
+	}
+
+	i := Info{
+		ClientIPAddress: e.clientIP(r),
+		ClientUserAgent: r.UserAgent(),
+		EventSourceURL:  e.url(r),
+		EventID:         NewEventID(),
+	}
+	if c, err := r.Cookie(fbpCookie); err == nil {
+		i.Fbp = c.Value
+	}
+	if c, err := r.Cookie(fbcCookie); err == nil {
+		i.Fbc = c.Value
+	}
+
+	// a new click replaces the click id of the cookie
+	fbclid := r.URL.Query().Get(fbclidParam)
+	if fbclid != "" && !strings.HasSuffix(i.Fbc, "."+fbclid) {
+		i.Fbc = NewFbc(now, fbclid)
+		i.fbcCreated = true
+	}
+
+	return i
+}
+
+// Middleware stores the Info of each request in its context, see FromContext.
+// If fbc is created from fbclid and CookieDomain is set, it is stored in the _fbc cookie.
+func (e *Extractor) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := e.Extract(r)
+		if i.fbcCreated && e.CookieDomain != "" {
+			http.SetCookie(w, &http.Cookie{
+				Name:     fbcCookie,
+				Value:    i.Fbc,
+				Domain:   e.CookieDomain,
+				Path:     "/",
+				MaxAge:   int(fbcLifetime.Seconds()),
+				Secure:   r.TLS != nil,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), i)))
+	})
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying i.
+func NewContext(ctx context.Context, i Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, i)
+}
+
+// FromContext returns the Info stored by the middleware.
+func FromContext(ctx context.Context) (Info, bool) {
+	i, ok := ctx.Value(contextKey{}).(Info)
+
+	return i, ok
+}
+
+// NewFbc returns the fbc parameter for a click id, formatted like the _fbc cookie of the pixel.
+func NewFbc(t time.Time, fbclid string) string {
+	return fmt.Sprintf(cookieFormat, 1, t.UnixMilli(), fbclid)
+}
+
+// NewEventID returns a random UUID to be used as event id.
+func NewEventID() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	s := hex.EncodeToString(b)
+
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// remoteAddr returns the address of the peer and whether its proxy headers are honoured.
+func (e *Extractor) remoteAddr(r *http.Request) (string, bool) {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+
+	return remote, e.trusted(net.ParseIP(remote))
+}
+
+func (e *Extractor) clientIP(r *http.Request) string {
+	remote, honoured := e.remoteAddr(r)
+	if !honoured {
+		return remote
+	}
+
+	for _, h := range e.ProxyHeaders {
+		values := r.Header.Values(h)
+		var ips []string
+		for _, v := range values {
+			for _, ip := range strings.Split(v, ",") {
+				ips = append(ips, strings.TrimSpace(ip))
+			}
+		}
+
+		// proxies append to the header, so the client is the last address not added by a trusted proxy
+		for j := len(ips) - 1; j >= 0; j-- {
+			ip := net.ParseIP(ips[j])
+			if ip == nil {
+				break
+			} else if j == 0 || !e.trusted(ip) {
+				return ip.String()
+			}
+		}
+	}
+
+	return remote
+}
+
+func (e *Extractor) trusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range e.TrustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *Extractor) url(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	} else if _, honoured := e.remoteAddr(r); honoured && len(e.ProxyHeaders) > 0 {
+		if p := r.Header.Get("X-Forwarded-Proto"); p == "http" || p == "https" {
+			scheme = p
+		}
+	}
+
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
@@ -0,0 +1,108 @@
+package tracking
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/marketing/types"
+)
+
+func TestClientIP(t *testing.T) {
+	trusting, err := New([]string{"X-Forwarded-For"}, "10.0.0.0/8", "192.168.1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	anyPeer, err := New([]string{"X-Forwarded-For"}, "0.0.0.0/0", "::/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, td := range []struct {
+		name   string
+		e      *Extractor
+		remote string
+		xff    string
+		out    string
+	}{
+		{"no proxy headers", &Extractor{}, "203.0.113.7:1234", "198.51.100.1", "203.0.113.7"},
+		{"untrusted peer", trusting, "203.0.113.7:1234", "198.51.100.1", "203.0.113.7"},
+		{"trusted peer", trusting, "10.1.2.3:1234", "198.51.100.1", "198.51.100.1"},
+		{"spoofed header", trusting, "10.1.2.3:1234", "1.1.1.1, 198.51.100.1, 10.0.0.2", "198.51.100.1"},
+		{"only proxies", trusting, "192.168.1.1:1234", "10.0.0.3, 10.0.0.2", "10.0.0.3"},
+		{"invalid header", trusting, "10.1.2.3:1234", "unknown", "10.1.2.3"},
+		{"no trusted proxies", &Extractor{ProxyHeaders: []string{"X-Forwarded-For"}}, "203.0.113.7:1234", "2001:db8::1", "203.0.113.7"},
+		{"any peer trusted", anyPeer, "203.0.113.7:1234", "2001:db8::1", "2001:db8::1"},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = td.remote
+			r.Header.Set("X-Forwarded-For", td.xff)
+			if got := td.e.Extract(r).ClientIPAddress; got != td.out {
+				t.Fatalf("client ip = %q, want %q", got, td.out)
+			}
+		})
+	}
+}
+
+func TestExtractFbc(t *testing.T) {
+	now := time.UnixMilli(1700000000123)
+	e := &Extractor{now: func() time.Time { return now }}
+
+	r := httptest.NewRequest(http.MethodGet, "https://example.com/landing?fbclid=AbC123", nil)
+	r.Header.Set("User-Agent", "test-agent")
+	r.AddCookie(&http.Cookie{Name: fbpCookie, Value: "fb.1.1600000000000.42"})
+	i := e.Extract(r)
+	if i.Fbc != "fb.1.1700000000123.AbC123" || i.Fbp != "fb.1.1600000000000.42" || i.ClientUserAgent != "test-agent" {
+		t.Fatalf("unexpected info: %+v", i)
+	}
+	if i.EventSourceURL != "https://example.com/landing?fbclid=AbC123" {
+		t.Fatalf("event source url = %q", i.EventSourceURL)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(i.EventID) {
+		t.Fatalf("event id %q is not a UUID", i.EventID)
+	}
+
+	// the cookie of the same click is kept
+	r.AddCookie(&http.Cookie{Name: fbcCookie, Value: "fb.1.1690000000000.AbC123"})
+	if i = e.Extract(r); i.Fbc != "fb.1.1690000000000.AbC123" {
+		t.Fatalf("fbc = %q, want the cookie value", i.Fbc)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	e := &Extractor{CookieDomain: "example.com"}
+	var info Info
+	h := e.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var ok bool
+		info, ok = FromContext(r.Context())
+		if !ok {
+			t.Fatal("no info in context")
+		}
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?fbclid=xyz", nil))
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != fbcCookie || cookies[0].Value != info.Fbc {
+		t.Fatalf("unexpected cookies %+v for fbc %q", cookies, info.Fbc)
+	}
+
+	ci := info.CustomerInformation()
+	if ci.Fbc != info.Fbc || ci.ClientIPAddress != "192.0.2.1" {
+		t.Fatalf("unexpected customer information: %+v", ci)
+	}
+}
+
+func TestApply(t *testing.T) {
+	e := types.ServerEvent{EventID: "own", UserData: types.NewCustomerInformation().WithFbp("fb.1.1.own").WithClientUserAgent("own-agent")}
+	e = Info{EventID: "new", EventSourceURL: "https://example.com", ClientIPAddress: "198.51.100.1"}.Apply(e)
+	if e.EventID != "own" || e.EventSourceURL != "https://example.com" || e.UserData.ClientIPAddress != "198.51.100.1" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if e.UserData.Fbp != "fb.1.1.own" || e.UserData.ClientUserAgent != "own-agent" {
+		t.Fatalf("empty info overwrote user data %+v", e.UserData)
+	}
+}
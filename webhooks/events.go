@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// notification is the body of a webhook POST request.
+type notification struct {
+	Object string  `json:"object"`
+	Entry  []entry `json:"entry"`
+}
+
+type entry struct {
+	ID      string   `json:"id"`
+	Time    int64    `json:"time"`
+	Changes []change `json:"changes"`
+}
+
+type change struct {
+	Field string          `json:"field"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Entry identifies the object a change happened on.
+type Entry struct {
+	// Object is the webhook object type, e.g. "page", "instagram" or "ad_account".
+	Object string
+	// ID is the id of the page, instagram account or ad account.
+	ID   string
+	Time time.Time
+}
+
+// Change is an undecoded change notification. It is passed to the OnChange callback
+// for all changes that are not handled by a typed callback.
+type Change struct {
+	Entry
+	Field string
+	Value json.RawMessage
+}
+
+// LeadgenEvent is sent for the leadgen field of a page when a lead form was submitted.
+// The lead itself has to be fetched using LeadgenID.
+type LeadgenEvent struct {
+	Entry       Entry  `json:"-"`
+	AdID        string `json:"ad_id"`
+	AdgroupID   string `json:"adgroup_id"`
+	FormID      string `json:"form_id"`
+	LeadgenID   string `json:"leadgen_id"`
+	PageID      string `json:"page_id"`
+	CreatedTime int64  `json:"created_time"`
+}
+
+// FeedEvent is sent for the feed field of a page, e.g. for new posts, comments and reactions.
+type FeedEvent struct {
+	Entry Entry `json:"-"`
+	// Item is the kind of object that changed, e.g. "post", "comment" or "reaction".
+	Item string `json:"item"`
+	// Verb is the kind of change, e.g. "add", "edited" or "remove".
+	Verb         string `json:"verb"`
+	PostID       string `json:"post_id"`
+	CommentID    string `json:"comment_id"`
+	ParentID     string `json:"parent_id"`
+	Message      string `json:"message"`
+	ReactionType string `json:"reaction_type"`
+	CreatedTime  int64  `json:"created_time"`
+	From         struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"from"`
+}
+
+// CommentEvent is sent for the comments field of an instagram account.
+type CommentEvent struct {
+	Entry    Entry  `json:"-"`
+	ID       string `json:"id"`
+	Text     string `json:"text"`
+	ParentID string `json:"parent_id"`
+	From     struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"from"`
+	Media struct {
+		ID               string `json:"id"`
+		MediaProductType string `json:"media_product_type"`
+	} `json:"media"`
+}
+
+// MentionEvent is sent for the mention field of a page and the mentions field of an instagram account.
+// Page mentions carry the post and sender, instagram mentions the media and comment.
+type MentionEvent struct {
+	Entry      Entry  `json:"-"`
+	Item       string `json:"item"`
+	Verb       string `json:"verb"`
+	PostID     string `json:"post_id"`
+	SenderID   string `json:"sender_id"`
+	SenderName string `json:"sender_name"`
+	MediaID    string `json:"media_id"`
+	CommentID  string `json:"comment_id"`
+}
+
+// AdAccountEvent is sent for status changes of ads, adsets and campaigns of an ad account,
+// e.g. for the with_issues_ad_objects and in_process_ad_objects fields.
+type AdAccountEvent struct {
+	Entry Entry  `json:"-"`
+	Field string `json:"-"`
+	// ID is the id of the ad object that changed, Level its type, e.g. "AD".
+	ID           string `json:"id"`
+	Level        string `json:"level"`
+	StatusName   string `json:"status_name"`
+	ErrorCode    int64  `json:"error_code"`
+	ErrorSummary string `json:"error_summary"`
+	ErrorMessage string `json:"error_message"`
+}
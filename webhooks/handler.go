@@ -0,0 +1,225 @@
+// Package webhooks receives Meta webhook notifications for pages, instagram accounts and ad accounts.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+const (
+	signatureHeader = "X-Hub-Signature-256"
+	signaturePrefix = "sha256="
+	maxBodySize     = 10 << 20
+)
+
+// Handler implements http.Handler for a webhook callback URL. It answers the verification
+// request sent when subscribing, checks the payload signature with the app secret of the
+// fb.Client and passes the decoded events to the registered callbacks.
+//
+// Callbacks have to be registered before the Handler starts serving. If a callback returns
+// an error, the request is answered with a 500 so Meta retries the delivery.
+type Handler struct {
+	l           log.Logger
+	c           *fb.Client
+	verifyToken string
+
+	onLeadgen   func(context.Context, LeadgenEvent) error
+	onFeed      func(context.Context, FeedEvent) error
+	onComment   func(context.Context, CommentEvent) error
+	onMention   func(context.Context, MentionEvent) error
+	onAdAccount func(context.Context, AdAccountEvent) error
+	onChange    func(context.Context, Change) error
+}
+
+// New returns a Handler verifying payloads with the app secret of c. verifyToken is the
+// token configured for the webhook subscription in the app dashboard. If c has no app secret,
+// all notifications are rejected as their signatures can't be verified.
+func New(l log.Logger, c *fb.Client, verifyToken string) *Handler {
+	if l == nil {
+		l = log.NewNopLogger()
+	}
+
+	return &Handler{
+		l:           l,
+		c:           c,
+		verifyToken: verifyToken,
+	}
+}
+
+// OnLeadgen registers the callback for the leadgen field of pages.
+func (h *Handler) OnLeadgen(f func(context.Context, LeadgenEvent) error) {
+	h.onLeadgen = f
+}
+
+// OnFeed registers the callback for the feed field of pages.
+func (h *Handler) OnFeed(f func(context.Context, FeedEvent) error) {
+	h.onFeed = f
+}
+
+// OnComment registers the callback for the comments field of instagram accounts.
+func (h *Handler) OnComment(f func(context.Context, CommentEvent) error) {
+	h.onComment = f
+}
+
+// OnMention registers the callback for page and instagram mentions.
+func (h *Handler) OnMention(f func(context.Context, MentionEvent) error) {
+	h.onMention = f
+}
+
+// OnAdAccount registers the callback for ad account changes.
+func (h *Handler) OnAdAccount(f func(context.Context, AdAccountEvent) error) {
+	h.onAdAccount = f
+}
+
+// OnChange registers the callback for all changes without a matching typed callback.
+func (h *Handler) OnChange(f func(context.Context, Change) error) {
+	h.onChange = f
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.verify(w, r)
+	case http.MethodPost:
+		h.receive(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) verify(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("hub.mode") != "subscribe" || h.verifyToken == "" || q.Get("hub.verify_token") != h.verifyToken {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = io.WriteString(w, q.Get("hub.challenge"))
+}
+
+func (h *Handler) receive(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+		return
+	}
+
+	if !h.validSignature(body, r.Header.Get(signatureHeader)) {
+		_ = level.Warn(h.l).Log("msg", "rejected webhook with invalid signature", "remote_addr", r.RemoteAddr)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+		return
+	}
+
+	n := notification{}
+	err = json.Unmarshal(body, &n)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+		return
+	}
+
+	err = h.dispatch(r.Context(), n)
+	if err != nil {
+		_ = level.Error(h.l).Log("msg", "err handling webhook", "object", n.Object, "err", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) validSignature(body []byte, header string) bool {
+	if !h.c.HasAppSecret() || !strings.HasPrefix(header, signaturePrefix) {
+		return false
+	}
+	expected := signaturePrefix + h.c.SignPayload(body)
+
+	return hmac.Equal([]byte(expected), []byte(header))
+}
+
+func (h *Handler) dispatch(ctx context.Context, n notification) error {
+	for _, e := range n.Entry {
+		en := Entry{
+			Object: n.Object,
+			ID:     e.ID,
+			Time:   time.Unix(e.Time, 0),
+		}
+		for _, c := range e.Changes {
+			err := h.dispatchChange(ctx, en, c)
+			if err != nil {
+				return fmt.Errorf("err handling %s change of %s %s: %w", c.Field, en.Object, en.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) dispatchChange(ctx context.Context, en Entry, c change) error {
+	switch {
+	case en.Object == "page" && c.Field == "leadgen" && h.onLeadgen != nil:
+		ev := LeadgenEvent{}
+		if err := json.Unmarshal(c.Value, &ev); err != nil {
+			return err
+		}
+		ev.Entry = en
+
+		return h.onLeadgen(ctx, ev)
+	case en.Object == "page" && c.Field == "feed" && h.onFeed != nil:
+		ev := FeedEvent{}
+		if err := json.Unmarshal(c.Value, &ev); err != nil {
+			return err
+		}
+		ev.Entry = en
+
+		return h.onFeed(ctx, ev)
+	case en.Object == "instagram" && (c.Field == "comments" || c.Field == "live_comments") && h.onComment != nil:
+		ev := CommentEvent{}
+		if err := json.Unmarshal(c.Value, &ev); err != nil {
+			return err
+		}
+		ev.Entry = en
+
+		return h.onComment(ctx, ev)
+	case (en.Object == "page" && c.Field == "mention" || en.Object == "instagram" && c.Field == "mentions") && h.onMention != nil:
+		ev := MentionEvent{}
+		if err := json.Unmarshal(c.Value, &ev); err != nil {
+			return err
+		}
+		ev.Entry = en
+
+		return h.onMention(ctx, ev)
+	case en.Object == "ad_account" && h.onAdAccount != nil:
+		ev := AdAccountEvent{}
+		if err := json.Unmarshal(c.Value, &ev); err != nil {
+			return err
+		}
+		ev.Entry = en
+		ev.Field = c.Field
+
+		return h.onAdAccount(ctx, ev)
+	case h.onChange != nil:
+		return h.onChange(ctx, Change{Entry: en, Field: c.Field, Value: c.Value})
+	}
+
+	_ = level.Debug(h.l).Log("msg", "no callback for webhook change", "object", en.Object, "field", c.Field)
+
+	return nil
+}
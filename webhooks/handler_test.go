@@ -0,0 +1,133 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/justwatch/facebook-marketing-api-golang-sdk/fb"
+)
+
+func newTestHandler() *Handler {
+	return New(log.NewNopLogger(), fb.NewClient(log.NewNopLogger(), "token", "secret"), "verify-me")
+}
+
+func signedRequest(h *Handler, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	r.Header.Set(signatureHeader, signaturePrefix+h.c.SignPayload([]byte(body)))
+
+	return r
+}
+
+func TestVerify(t *testing.T) {
+	h := newTestHandler()
+
+	for _, td := range []struct {
+		query string
+		code  int
+		body  string
+	}{
+		{"hub.mode=subscribe&hub.verify_token=verify-me&hub.challenge=42", http.StatusOK, "42"},
+		{"hub.mode=subscribe&hub.verify_token=wrong&hub.challenge=42", http.StatusForbidden, ""},
+		{"hub.verify_token=verify-me&hub.challenge=42", http.StatusForbidden, ""},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks?"+td.query, nil))
+		if w.Code != td.code {
+			t.Fatalf("%s: status = %d, want %d", td.query, w.Code, td.code)
+		}
+		if td.code == http.StatusOK && w.Body.String() != td.body {
+			t.Fatalf("%s: body = %q, want %q", td.query, w.Body.String(), td.body)
+		}
+	}
+}
+
+func TestReceiveRejectsInvalidSignature(t *testing.T) {
+	h := newTestHandler()
+	h.OnChange(func(context.Context, Change) error {
+		t.Fatal("callback called for unsigned payload")
+
+		return nil
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{"object":"page","entry":[]}`))
+	r.Header.Set(signatureHeader, "sha256=00")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestReceiveRejectsWithoutAppSecret(t *testing.T) {
+	h := New(log.NewNopLogger(), fb.NewClient(log.NewNopLogger(), "token", ""), "verify-me")
+	h.OnChange(func(context.Context, Change) error {
+		t.Fatal("callback called without app secret")
+
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(h, `{"object":"page","entry":[]}`))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestReceiveDispatchesEvents(t *testing.T) {
+	h := newTestHandler()
+	var leads []LeadgenEvent
+	var changes []Change
+	h.OnLeadgen(func(_ context.Context, ev LeadgenEvent) error {
+		leads = append(leads, ev)
+
+		return nil
+	})
+	h.OnChange(func(_ context.Context, c Change) error {
+		changes = append(changes, c)
+
+		return nil
+	})
+
+	body := `{"object":"page","entry":[{"id":"p1","time":1700000000,"changes":[
+		{"field":"leadgen","value":{"ad_id":"a1","form_id":"f1","leadgen_id":"l1","page_id":"p1","created_time":1700000000}},
+		{"field":"feed","value":{"item":"comment","verb":"add"}}
+	]}]}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(h, body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	if len(leads) != 1 || leads[0].LeadgenID != "l1" || leads[0].Entry.ID != "p1" || leads[0].Entry.Time.Unix() != 1700000000 {
+		t.Fatalf("unexpected leadgen events: %+v", leads)
+	}
+	// no feed callback is registered, so the change falls through to OnChange
+	if len(changes) != 1 || changes[0].Field != "feed" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestReceiveCallbackError(t *testing.T) {
+	h := newTestHandler()
+	h.OnAdAccount(func(_ context.Context, ev AdAccountEvent) error {
+		if ev.Field != "with_issues_ad_objects" || ev.ErrorCode != 1815869 {
+			t.Fatalf("unexpected ad account event: %+v", ev)
+		}
+
+		return errors.New("try again")
+	})
+
+	body := `{"object":"ad_account","entry":[{"id":"act_1","time":1,"changes":[
+		{"field":"with_issues_ad_objects","value":{"id":"123","level":"AD","error_code":1815869}}
+	]}]}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest(h, body))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}